@@ -0,0 +1,103 @@
+package env
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Checker accumulates the errors of many lookups, so every missing or malformed value can be reported at once
+// instead of one at a time. The zero value is ready to use and retrieves values from Default.
+//
+//	c := env.NewChecker(env.Prefix("APP"))
+//	port := env.Require[uint16](c, "PORT")
+//	timeout := env.Optional(c, "TIMEOUT", 5*time.Second)
+//	if err := c.Err(); err != nil {
+//		log.Fatal(err)
+//	}
+type Checker struct {
+	scope Scope
+
+	mu   sync.Mutex
+	errs []error
+}
+
+// NewChecker returns a Checker that retrieves values from s.
+func NewChecker(s Scope) *Checker {
+	return &Checker{scope: s}
+}
+
+// Check records err, if it is not nil, and reports whether it was nil.
+// It can be used to collect the errors of the E getters, e.g. GetIntE.
+func (c *Checker) Check(err error) bool {
+	if err == nil {
+		return true
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.errs = append(c.errs, err)
+	return false
+}
+
+// Err returns a *CheckError listing every recorded error, or nil if none were recorded.
+func (c *Checker) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.errs) == 0 {
+		return nil
+	}
+	return &CheckError{Errs: append([]error(nil), c.errs...)}
+}
+
+func (c *Checker) getScope() Scope {
+	if c.scope == nil {
+		return Default
+	}
+	return c.scope
+}
+
+// Require retrieves a required T named by key using c.
+// If the value is not present, is not a valid T or violates any of rules, the error is recorded by c and the zero
+// value of T is returned.
+func Require[T any](c *Checker, key string, rules ...Rule[T]) T {
+	v, err := ParseIn(c.getScope(), key, rules...)
+	c.Check(err)
+	return v
+}
+
+// Optional retrieves an optional T named by key using c. If the value is not present, def is returned instead.
+// If the value is not a valid T or violates any of rules, the error is recorded by c and def is returned.
+func Optional[T any](c *Checker, key string, def T, rules ...Rule[T]) T {
+	e, name := c.getScope().scope(key)
+	defer supplyDefault(e, name, def)
+
+	v, err := ParseIn(c.getScope(), key, rules...)
+	if errors.Is(err, ErrNotSet) || !c.Check(err) {
+		return useDefault(e, name, def)
+	}
+	return v
+}
+
+// CheckError lists the errors recorded by a Checker.
+type CheckError struct {
+	Errs []error
+}
+
+func (e *CheckError) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "env: %d invalid variable(s):", len(e.Errs))
+	for _, err := range e.Errs {
+		sb.WriteString("\n\t")
+		sb.WriteString(strings.TrimPrefix(err.Error(), "env: "))
+	}
+	return sb.String()
+}
+
+// Unwrap returns the recorded errors, so they can be tested with errors.Is and errors.As.
+func (e *CheckError) Unwrap() []error {
+	return e.Errs
+}
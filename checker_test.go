@@ -0,0 +1,51 @@
+package env
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChecker(t *testing.T) {
+	e := New(Map{
+		"APP_PORT":    "8080",
+		"APP_HOST":    "localhost",
+		"APP_WORKERS": "four",
+		"APP_RETRIES": "x",
+	})
+
+	c := NewChecker(e)
+	require.Equal(t, uint16(8080), Require[uint16](c, "APP_PORT"))
+	require.Equal(t, "localhost", Require[string](c, "APP_HOST"))
+	require.Equal(t, 0, Require[int](c, "APP_WORKERS"))
+	require.Equal(t, time.Duration(0), Require[time.Duration](c, "APP_TIMEOUT"))
+	require.Equal(t, time.Second, Optional(c, "APP_INTERVAL", time.Second))
+	require.Equal(t, 3, Optional(c, "APP_RETRIES", 3))
+
+	_, err := e.GetBoolE("APP_DEBUG")
+	require.False(t, c.Check(err))
+	require.True(t, c.Check(nil))
+
+	err = c.Err()
+	require.EqualError(t, err, `env: 4 invalid variable(s):
+	APP_WORKERS: parsing "four" as int: invalid syntax
+	APP_TIMEOUT: not set, expected time.Duration
+	APP_RETRIES: parsing "x" as int: invalid syntax
+	APP_DEBUG: not set, expected bool`)
+
+	var checkErr *CheckError
+	require.True(t, errors.As(err, &checkErr))
+	require.Len(t, checkErr.Errs, 4)
+	require.True(t, errors.Is(err, ErrNotSet))
+
+	var parseErr *ParseError
+	require.True(t, errors.As(err, &parseErr))
+	require.Equal(t, "APP_WORKERS", parseErr.Key)
+}
+
+func TestChecker_Zero(t *testing.T) {
+	var c Checker
+	require.NoError(t, c.Err())
+}
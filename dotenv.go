@@ -0,0 +1,351 @@
+package env
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// SyntaxError records a malformed line of a .env file.
+type SyntaxError struct {
+	Filename string // the name of the file, if known
+	Line     int    // the 1-based line number
+	Msg      string // a description of the error
+}
+
+func (e *SyntaxError) Error() string {
+	if e.Filename == "" {
+		return fmt.Sprintf("env: line %d: %s", e.Line, e.Msg)
+	}
+	return fmt.Sprintf("env: %s:%d: %s", e.Filename, e.Line, e.Msg)
+}
+
+// ParseDotenv parses the contents of a .env file read from r.
+//
+// Each line is of the form KEY=value, optionally preceded by "export". Blank lines and lines starting with # are
+// ignored. Unquoted values are trimmed and may be followed by a # comment. Single-quoted values are taken literally.
+// Double-quoted values may span multiple lines and support the escape sequences \n, \r, \t, \", \\ and \$.
+func ParseDotenv(r io.Reader) (Map, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &dotenvParser{src: string(b), line: 1}
+	return p.parse()
+}
+
+// DotenvFile is a read-only Source holding the variables of a .env file, which records the line each variable is
+// defined on. Unlike a Map, it does not implement Setter, so the typed setters cannot desynchronize it from its file.
+type DotenvFile struct {
+	Filename string
+	vars     Map
+	lines    map[string]int
+}
+
+// OpenDotenv parses the .env file named by filename into a DotenvFile.
+func OpenDotenv(filename string) (*DotenvFile, error) {
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &dotenvParser{src: string(b), line: 1, lines: map[string]int{}}
+	m, err := p.parse()
+	if err != nil {
+		err.(*SyntaxError).Filename = filename
+		return nil, err
+	}
+	return &DotenvFile{Filename: filename, vars: m, lines: p.lines}, nil
+}
+
+// Lookup retrieves the value named by key and reports whether it is defined in the file.
+func (f *DotenvFile) Lookup(key string) (string, bool) {
+	return f.vars.Lookup(key)
+}
+
+// Keys returns the names of the variables defined in the file in sorted order.
+func (f *DotenvFile) Keys() []string {
+	return f.vars.Keys()
+}
+
+// Locate returns the name of the file and the 1-based line the variable named by key is defined on.
+func (f *DotenvFile) Locate(key string) (string, int, bool) {
+	line, ok := f.lines[key]
+	if !ok {
+		return "", 0, false
+	}
+	return f.Filename, line, true
+}
+
+// ReadDotenv parses the .env file named by filename.
+// The returned Map can be used as the Source of an Env to retrieve typed values without modifying the process environment.
+// It is a copy, so the typed setters modify it but not the file; use OpenDotenv for a read-only Source.
+func ReadDotenv(filename string) (Map, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	m, err := ParseDotenv(f)
+	if err, ok := err.(*SyntaxError); ok {
+		err.Filename = filename
+	}
+	return m, err
+}
+
+// LoadDotenv sets the variables of the named .env files in the process environment.
+// Variables that are already present are not overridden, and files are applied in order, so a variable defined in more
+// than one file takes the value of the first.
+func LoadDotenv(filenames ...string) error {
+	return loadDotenv(filenames, false)
+}
+
+// OverloadDotenv sets the variables of the named .env files in the process environment.
+// Variables that are already present are overridden, and files are applied in order, so a variable defined in more
+// than one file takes the value of the last.
+func OverloadDotenv(filenames ...string) error {
+	return loadDotenv(filenames, true)
+}
+
+func loadDotenv(filenames []string, override bool) error {
+	for _, filename := range filenames {
+		m, err := ReadDotenv(filename)
+		if err != nil {
+			return err
+		}
+		for _, k := range m.Keys() {
+			if _, ok := os.LookupEnv(k); ok && !override {
+				continue
+			}
+			if err := os.Setenv(k, m[k]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+type dotenvParser struct {
+	src   string
+	pos   int
+	line  int
+	lines map[string]int // the line each key is defined on, if not nil
+}
+
+func (p *dotenvParser) parse() (Map, error) {
+	m := Map{}
+	for {
+		p.skipBlank()
+		if p.eof() {
+			return m, nil
+		}
+		if p.peek() == '#' {
+			p.skipLine()
+			continue
+		}
+
+		line := p.line
+		key, err := p.parseKey()
+		if err != nil {
+			return nil, err
+		}
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		m[key] = value
+		if p.lines != nil {
+			p.lines[key] = line
+		}
+	}
+}
+
+func (p *dotenvParser) parseKey() (string, error) {
+	if strings.HasPrefix(p.src[p.pos:], "export") {
+		rest := p.src[p.pos+len("export"):]
+		if len(rest) > 0 && (rest[0] == ' ' || rest[0] == '\t') {
+			p.pos += len("export")
+			p.skipSpace()
+		}
+	}
+
+	start := p.pos
+	for !p.eof() && isKeyChar(p.peek(), p.pos == start) {
+		p.pos++
+	}
+	key := p.src[start:p.pos]
+	if key == "" {
+		return "", p.errorf("invalid variable name")
+	}
+
+	p.skipSpace()
+	if p.eof() || p.peek() != '=' {
+		return "", p.errorf("expected '=' after %s", key)
+	}
+	p.pos++
+	p.skipSpace()
+	return key, nil
+}
+
+func (p *dotenvParser) parseValue() (string, error) {
+	if p.eof() {
+		return "", nil
+	}
+
+	var (
+		value string
+		err   error
+	)
+	switch p.peek() {
+	case '\'':
+		value, err = p.parseSingleQuoted()
+	case '"':
+		value, err = p.parseDoubleQuoted()
+	default:
+		return p.parseUnquoted(), nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	p.skipSpace()
+	if !p.eof() && p.peek() != '\n' && p.peek() != '\r' && p.peek() != '#' {
+		return "", p.errorf("unexpected character %q after quoted value", p.peek())
+	}
+	p.skipLine()
+	return value, nil
+}
+
+func (p *dotenvParser) parseUnquoted() string {
+	start := p.pos
+	end := p.pos
+	for !p.eof() && p.peek() != '\n' {
+		if p.peek() == '#' && p.pos > 0 && isSpace(p.src[p.pos-1]) {
+			break
+		}
+		p.pos++
+		end = p.pos
+	}
+	p.skipLine()
+	return strings.TrimSpace(p.src[start:end])
+}
+
+func (p *dotenvParser) parseSingleQuoted() (string, error) {
+	line := p.line
+	p.pos++
+	start := p.pos
+	for !p.eof() && p.peek() != '\'' {
+		if p.peek() == '\n' {
+			p.line = line
+			return "", p.errorf("unterminated single-quoted value")
+		}
+		p.pos++
+	}
+	if p.eof() {
+		p.line = line
+		return "", p.errorf("unterminated single-quoted value")
+	}
+	value := p.src[start:p.pos]
+	p.pos++
+	return value, nil
+}
+
+func (p *dotenvParser) parseDoubleQuoted() (string, error) {
+	line := p.line
+	p.pos++
+
+	var sb strings.Builder
+	for !p.eof() {
+		c := p.peek()
+		switch c {
+		case '"':
+			p.pos++
+			return sb.String(), nil
+		case '\\':
+			p.pos++
+			if p.eof() {
+				break
+			}
+			switch e := p.peek(); e {
+			case 'n':
+				sb.WriteByte('\n')
+			case 'r':
+				sb.WriteByte('\r')
+			case 't':
+				sb.WriteByte('\t')
+			case '"', '\\', '$':
+				sb.WriteByte(e)
+			default:
+				return "", p.errorf("invalid escape sequence \\%c", e)
+			}
+		case '\n':
+			p.line++
+			sb.WriteByte(c)
+		default:
+			sb.WriteByte(c)
+		}
+		p.pos++
+	}
+
+	p.line = line
+	return "", p.errorf("unterminated double-quoted value")
+}
+
+func (p *dotenvParser) eof() bool {
+	return p.pos >= len(p.src)
+}
+
+func (p *dotenvParser) peek() byte {
+	return p.src[p.pos]
+}
+
+func (p *dotenvParser) skipSpace() {
+	for !p.eof() && isSpace(p.peek()) {
+		p.pos++
+	}
+}
+
+func (p *dotenvParser) skipBlank() {
+	for !p.eof() {
+		switch p.peek() {
+		case ' ', '\t', '\r':
+		case '\n':
+			p.line++
+		default:
+			return
+		}
+		p.pos++
+	}
+}
+
+func (p *dotenvParser) skipLine() {
+	for !p.eof() {
+		c := p.peek()
+		p.pos++
+		if c == '\n' {
+			p.line++
+			return
+		}
+	}
+}
+
+func (p *dotenvParser) errorf(format string, args ...interface{}) error {
+	return &SyntaxError{Line: p.line, Msg: fmt.Sprintf(format, args...)}
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t'
+}
+
+func isKeyChar(c byte, first bool) bool {
+	switch {
+	case c == '_', 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z':
+		return true
+	case '0' <= c && c <= '9', c == '.':
+		return !first
+	}
+	return false
+}
@@ -0,0 +1,141 @@
+package env
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDotenv(t *testing.T) {
+	src := `# a comment
+PLAIN=value
+SPACED = spaced value   
+export EXPORTED=exported
+EMPTY=
+COMMENTED= # a comment
+INLINE=value # a comment
+HASH=a#b
+SINGLE='single $VAR \n # not a comment'
+DOUBLE="double \"quoted\"\tvalue\\ \$VAR" # a comment
+MULTI="line one
+line two"
+
+CRLF=crlf` + "\r\n" + `AFTER=after
+`
+
+	m, err := ParseDotenv(strings.NewReader(src))
+	require.NoError(t, err)
+	require.Equal(t, Map{
+		"PLAIN":     "value",
+		"SPACED":    "spaced value",
+		"EXPORTED":  "exported",
+		"EMPTY":     "",
+		"COMMENTED": "",
+		"INLINE":    "value",
+		"HASH":      "a#b",
+		"SINGLE":    `single $VAR \n # not a comment`,
+		"DOUBLE":    "double \"quoted\"\tvalue\\ $VAR",
+		"MULTI":     "line one\nline two",
+		"CRLF":      "crlf",
+		"AFTER":     "after",
+	}, m)
+}
+
+func TestParseDotenv_SyntaxError(t *testing.T) {
+	tests := []struct {
+		src  string
+		line int
+	}{
+		{src: "FOO", line: 1},
+		{src: "FOO=bar\n1FOO=bar", line: 2},
+		{src: "FOO=bar\n\nBAR=\"unterminated\nvalue", line: 3},
+		{src: "FOO='unterminated\n'", line: 1},
+		{src: "FOO=\"bad \\q escape\"", line: 1},
+		{src: "FOO=\"a\nb\" trailing", line: 2},
+	}
+
+	for _, test := range tests {
+		t.Run(test.src, func(t *testing.T) {
+			_, err := ParseDotenv(strings.NewReader(test.src))
+
+			var syntaxErr *SyntaxError
+			require.True(t, errors.As(err, &syntaxErr))
+			require.Equal(t, test.line, syntaxErr.Line)
+		})
+	}
+}
+
+func TestReadDotenv(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, ".env")
+	require.NoError(t, os.WriteFile(filename, []byte("PORT=8080\nDEBUG=yes\n"), 0600))
+
+	m, err := ReadDotenv(filename)
+	require.NoError(t, err)
+
+	e := New(m)
+	require.Equal(t, 8080, e.GetInt("PORT"))
+	require.Equal(t, true, e.GetBool("DEBUG"))
+
+	require.NoError(t, os.WriteFile(filename, []byte("PORT\n"), 0600))
+	_, err = ReadDotenv(filename)
+	require.EqualError(t, err, "env: "+filename+":1: expected '=' after PORT")
+}
+
+func TestOpenDotenv(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, ".env")
+	require.NoError(t, os.WriteFile(filename, []byte("# comment\nPORT=8080\nKEY=\"multi\nline\"\nDEBUG=yes\n"), 0600))
+
+	f, err := OpenDotenv(filename)
+	require.NoError(t, err)
+	require.Equal(t, 8080, New(f).GetInt("PORT"))
+
+	for key, line := range map[string]int{"PORT": 2, "KEY": 3, "DEBUG": 5} {
+		file, l, ok := f.Locate(key)
+		require.True(t, ok)
+		require.Equal(t, filename, file)
+		require.Equal(t, line, l, key)
+	}
+	_, _, ok := f.Locate("MISSING")
+	require.False(t, ok)
+	require.Equal(t, []string{"DEBUG", "KEY", "PORT"}, f.Keys())
+
+	var src Source = f
+	_, ok = src.(Setter)
+	require.False(t, ok)
+	require.Error(t, New(f).SetInt("PORT", 9090))
+	require.Equal(t, 8080, New(f).GetInt("PORT"))
+
+	require.NoError(t, os.WriteFile(filename, []byte("PORT\n"), 0600))
+	_, err = OpenDotenv(filename)
+	require.EqualError(t, err, "env: "+filename+":1: expected '=' after PORT")
+}
+
+func TestLoadDotenv(t *testing.T) {
+	dir := t.TempDir()
+	first := filepath.Join(dir, ".env")
+	second := filepath.Join(dir, ".env.local")
+	require.NoError(t, os.WriteFile(first, []byte("DOTENV_A=first\nDOTENV_B=first\n"), 0600))
+	require.NoError(t, os.WriteFile(second, []byte("DOTENV_B=second\nDOTENV_C=second\n"), 0600))
+
+	t.Setenv("DOTENV_A", "existing")
+	unsetenv(t, "DOTENV_B")
+	unsetenv(t, "DOTENV_C")
+
+	require.NoError(t, LoadDotenv(first, second))
+	require.Equal(t, "existing", Get("DOTENV_A"))
+	require.Equal(t, "first", Get("DOTENV_B"))
+	require.Equal(t, "second", Get("DOTENV_C"))
+
+	require.NoError(t, OverloadDotenv(first, second))
+	require.Equal(t, "first", Get("DOTENV_A"))
+	require.Equal(t, "second", Get("DOTENV_B"))
+	require.Equal(t, "second", Get("DOTENV_C"))
+
+	require.Error(t, LoadDotenv(filepath.Join(dir, "missing")))
+}
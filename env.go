@@ -1,313 +1,938 @@
 package env
 
 import (
-	"fmt"
-	"os"
 	"strconv"
 	"strings"
 	"time"
 )
 
-// Get wraps os.Getenv.
-func Get(key string) string {
-	return os.Getenv(key)
+// Env retrieves typed values from a Source.
+// The zero value is ready to use and retrieves values from OS.
+type Env struct {
+	// Source provides the values retrieved by the Env. If nil, OS is used.
+	Source Source
+
+	// LegacyDefaults restores the original behavior of the numeric D getters, e.g. GetIntD, where a value parsed as
+	// zero is treated as not present and def is returned instead. By default, a value explicitly set to zero is honored.
+	LegacyDefaults bool
+
+	// Expand enables the expansion of references to other variables in retrieved values, e.g. ${HOST:-localhost}.
+	// See Expand for the supported forms.
+	Expand bool
+
+	// ListSeparator separates the elements of list values, e.g. GetInts. If empty, DefaultListSeparator is used.
+	ListSeparator string
+
+	// MapSeparator separates the entries of map values, e.g. GetIntMap. If empty, DefaultMapSeparator is used.
+	MapSeparator string
+
+	// KeyValueSeparator separates the key and value of each entry of map values.
+	// If empty, DefaultKeyValueSeparator is used.
+	KeyValueSeparator string
+
+	// Files enables reading the value of a variable KEY from the file named by KEY_FILE when KEY is not present or
+	// empty, the convention used for Docker and Kubernetes secrets. The trailing newline of the file is removed.
+	// When Expand is also enabled, references are resolved the same way, but the contents of files are not expanded.
+	Files bool
+
+	// MaxFileSize limits the size of the files read when Files is enabled. If zero, DefaultMaxFileSize is used.
+	MaxFileSize int64
+
+	// Tracker records the provenance of each lookup, if not nil. See Tracker.Explain.
+	Tracker *Tracker
 }
 
-// Lookup wraps os.LookupEnv.
-func Lookup(key string) (string, bool) {
-	return os.LookupEnv(key)
+// Default is the Env used by the package-level functions and Prefix.
+var Default = New(OS)
+
+// New returns an Env that retrieves values from src.
+func New(src Source) *Env {
+	return &Env{Source: src}
+}
+
+// Get retrieves the value named by key.
+func (e *Env) Get(key string) string {
+	v, _ := e.Lookup(key)
+	return v
+}
+
+// Lookup retrieves the value named by key and reports whether it is present.
+// If the value cannot be expanded, it is reported as not present; use GetStringE to retrieve the error.
+func (e *Env) Lookup(key string) (string, bool) {
+	v, ok, err := e.lookup(key)
+	if err != nil {
+		return "", false
+	}
+	return v, ok
 }
 
 // GetD attempts to retrieve a string named by key. If the value is not present, def is returned instead.
-func GetD(key string, def string) string {
-	v, ok := Lookup(key)
+func (e *Env) GetD(key string, def string) string {
+	defer supplyDefault(e, key, def)
+	v, ok := e.Lookup(key)
 	if !ok || v == "" {
-		return def
+		return useDefault(e, key, def)
 	}
 	return v
 }
 
 // GetString retrieves a string named by key.
 // It is functionally the same as Get.
-func GetString(key string) string {
-	return Get(key)
+func (e *Env) GetString(key string) string {
+	return e.Get(key)
 }
 
 // GetStringD attempts to retrieve a string named by key. If the value is not present, def is returned instead.
 // It is functionally the same as GetD.
-func GetStringD(key string, def string) string {
-	if v := GetString(key); v != "" {
+func (e *Env) GetStringD(key string, def string) string {
+	defer supplyDefault(e, key, def)
+	if v := e.GetString(key); v != "" {
 		return v
 	}
-	return def
+	return useDefault(e, key, def)
+}
+
+// GetStringE retrieves a string named by key.
+// A *NotSetError is returned if the value is not present.
+func (e *Env) GetStringE(key string) (string, error) {
+	return e.lookupE(key, "string")
+}
+
+// GetInt retrieves an int named by key.
+// int(0) is returned if the value does not exist or is not a valid int.
+func (e *Env) GetInt(key string) int {
+	v, _ := e.GetIntE(key)
+	return v
+}
+
+// GetIntD attempts to retrieve an int named by key. If the value is not present, def is returned instead.
+func (e *Env) GetIntD(key string, def int) int {
+	defer supplyDefault(e, key, def)
+	v, err := e.GetIntE(key)
+	if err != nil || (e.LegacyDefaults && v == 0) {
+		return useDefault(e, key, def)
+	}
+	return v
+}
+
+// GetIntE retrieves an int named by key.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it is not a valid int.
+func (e *Env) GetIntE(key string) (int, error) {
+	s, err := e.lookupE(key, "int")
+	if err != nil {
+		return 0, err
+	}
+	v, err := parseInt(s)
+	if err != nil {
+		return 0, e.parseError(key, s, "int", err)
+	}
+	return v, nil
+}
+
+// GetInt8 retrieves an int8 named by key.
+// int8(0) is returned if the value does not exist or is not a valid int8.
+func (e *Env) GetInt8(key string) int8 {
+	v, _ := e.GetInt8E(key)
+	return v
+}
+
+// GetInt8D attempts to retrieve an int8 named by key. If the value is not present, def is returned instead.
+func (e *Env) GetInt8D(key string, def int8) int8 {
+	defer supplyDefault(e, key, def)
+	v, err := e.GetInt8E(key)
+	if err != nil || (e.LegacyDefaults && v == 0) {
+		return useDefault(e, key, def)
+	}
+	return v
+}
+
+// GetInt8E retrieves an int8 named by key.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it is not a valid int8.
+func (e *Env) GetInt8E(key string) (int8, error) {
+	s, err := e.lookupE(key, "int8")
+	if err != nil {
+		return 0, err
+	}
+	v, err := parseInt8(s)
+	if err != nil {
+		return 0, e.parseError(key, s, "int8", err)
+	}
+	return v, nil
+}
+
+// GetInt16 retrieves an int16 named by key.
+// int16(0) is returned if the value does not exist or is not a valid int16.
+func (e *Env) GetInt16(key string) int16 {
+	v, _ := e.GetInt16E(key)
+	return v
+}
+
+// GetInt16D attempts to retrieve an int16 named by key. If the value is not present, def is returned instead.
+func (e *Env) GetInt16D(key string, def int16) int16 {
+	defer supplyDefault(e, key, def)
+	v, err := e.GetInt16E(key)
+	if err != nil || (e.LegacyDefaults && v == 0) {
+		return useDefault(e, key, def)
+	}
+	return v
+}
+
+// GetInt16E retrieves an int16 named by key.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it is not a valid int16.
+func (e *Env) GetInt16E(key string) (int16, error) {
+	s, err := e.lookupE(key, "int16")
+	if err != nil {
+		return 0, err
+	}
+	v, err := parseInt16(s)
+	if err != nil {
+		return 0, e.parseError(key, s, "int16", err)
+	}
+	return v, nil
+}
+
+// GetInt32 retrieves an int32 named by key.
+// int32(0) is returned if the value does not exist or is not a valid int32.
+func (e *Env) GetInt32(key string) int32 {
+	v, _ := e.GetInt32E(key)
+	return v
+}
+
+// GetInt32D attempts to retrieve an int32 named by key. If the value is not present, def is returned instead.
+func (e *Env) GetInt32D(key string, def int32) int32 {
+	defer supplyDefault(e, key, def)
+	v, err := e.GetInt32E(key)
+	if err != nil || (e.LegacyDefaults && v == 0) {
+		return useDefault(e, key, def)
+	}
+	return v
+}
+
+// GetInt32E retrieves an int32 named by key.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it is not a valid int32.
+func (e *Env) GetInt32E(key string) (int32, error) {
+	s, err := e.lookupE(key, "int32")
+	if err != nil {
+		return 0, err
+	}
+	v, err := parseInt32(s)
+	if err != nil {
+		return 0, e.parseError(key, s, "int32", err)
+	}
+	return v, nil
+}
+
+// GetInt64 retrieves an int64 named by key.
+// int64(0) is returned if the value does not exist or is not a valid int64.
+func (e *Env) GetInt64(key string) int64 {
+	v, _ := e.GetInt64E(key)
+	return v
+}
+
+// GetInt64D attempts to retrieve an int64 named by key. If the value is not present, def is returned instead.
+func (e *Env) GetInt64D(key string, def int64) int64 {
+	defer supplyDefault(e, key, def)
+	v, err := e.GetInt64E(key)
+	if err != nil || (e.LegacyDefaults && v == 0) {
+		return useDefault(e, key, def)
+	}
+	return v
+}
+
+// GetInt64E retrieves an int64 named by key.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it is not a valid int64.
+func (e *Env) GetInt64E(key string) (int64, error) {
+	s, err := e.lookupE(key, "int64")
+	if err != nil {
+		return 0, err
+	}
+	v, err := parseInt64(s)
+	if err != nil {
+		return 0, e.parseError(key, s, "int64", err)
+	}
+	return v, nil
+}
+
+// GetUInt retrieves an uint named by key.
+// uint(0) is returned if the value does not exist or is not a valid uint.
+func (e *Env) GetUInt(key string) uint {
+	v, _ := e.GetUIntE(key)
+	return v
+}
+
+// GetUIntD attempts to retrieve an uint named by key. If the value is not present, def is returned instead.
+func (e *Env) GetUIntD(key string, def uint) uint {
+	defer supplyDefault(e, key, def)
+	v, err := e.GetUIntE(key)
+	if err != nil || (e.LegacyDefaults && v == 0) {
+		return useDefault(e, key, def)
+	}
+	return v
+}
+
+// GetUIntE retrieves an uint named by key.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it is not a valid uint.
+func (e *Env) GetUIntE(key string) (uint, error) {
+	s, err := e.lookupE(key, "uint")
+	if err != nil {
+		return 0, err
+	}
+	v, err := parseUInt(s)
+	if err != nil {
+		return 0, e.parseError(key, s, "uint", err)
+	}
+	return v, nil
+}
+
+// GetUInt8 retrieves an uint8 named by key.
+// uint8(0) is returned if the value does not exist or is not a valid uint8.
+func (e *Env) GetUInt8(key string) uint8 {
+	v, _ := e.GetUInt8E(key)
+	return v
+}
+
+// GetUInt8D attempts to retrieve an uint8 named by key. If the value is not present, def is returned instead.
+func (e *Env) GetUInt8D(key string, def uint8) uint8 {
+	defer supplyDefault(e, key, def)
+	v, err := e.GetUInt8E(key)
+	if err != nil || (e.LegacyDefaults && v == 0) {
+		return useDefault(e, key, def)
+	}
+	return v
+}
+
+// GetUInt8E retrieves an uint8 named by key.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it is not a valid uint8.
+func (e *Env) GetUInt8E(key string) (uint8, error) {
+	s, err := e.lookupE(key, "uint8")
+	if err != nil {
+		return 0, err
+	}
+	v, err := parseUInt8(s)
+	if err != nil {
+		return 0, e.parseError(key, s, "uint8", err)
+	}
+	return v, nil
+}
+
+// GetUInt16 retrieves an uint16 named by key.
+// uint16(0) is returned if the value does not exist or is not a valid uint16.
+func (e *Env) GetUInt16(key string) uint16 {
+	v, _ := e.GetUInt16E(key)
+	return v
+}
+
+// GetUInt16D attempts to retrieve an uint16 named by key. If the value is not present, def is returned instead.
+func (e *Env) GetUInt16D(key string, def uint16) uint16 {
+	defer supplyDefault(e, key, def)
+	v, err := e.GetUInt16E(key)
+	if err != nil || (e.LegacyDefaults && v == 0) {
+		return useDefault(e, key, def)
+	}
+	return v
+}
+
+// GetUInt16E retrieves an uint16 named by key.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it is not a valid uint16.
+func (e *Env) GetUInt16E(key string) (uint16, error) {
+	s, err := e.lookupE(key, "uint16")
+	if err != nil {
+		return 0, err
+	}
+	v, err := parseUInt16(s)
+	if err != nil {
+		return 0, e.parseError(key, s, "uint16", err)
+	}
+	return v, nil
+}
+
+// GetUInt32 retrieves an uint32 named by key.
+// uint32(0) is returned if the value does not exist or is not a valid uint32.
+func (e *Env) GetUInt32(key string) uint32 {
+	v, _ := e.GetUInt32E(key)
+	return v
+}
+
+// GetUInt32D attempts to retrieve an uint32 named by key. If the value is not present, def is returned instead.
+func (e *Env) GetUInt32D(key string, def uint32) uint32 {
+	defer supplyDefault(e, key, def)
+	v, err := e.GetUInt32E(key)
+	if err != nil || (e.LegacyDefaults && v == 0) {
+		return useDefault(e, key, def)
+	}
+	return v
+}
+
+// GetUInt32E retrieves an uint32 named by key.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it is not a valid uint32.
+func (e *Env) GetUInt32E(key string) (uint32, error) {
+	s, err := e.lookupE(key, "uint32")
+	if err != nil {
+		return 0, err
+	}
+	v, err := parseUInt32(s)
+	if err != nil {
+		return 0, e.parseError(key, s, "uint32", err)
+	}
+	return v, nil
+}
+
+// GetUInt64 retrieves an uint64 named by key.
+// uint64(0) is returned if the value does not exist or is not a valid uint64.
+func (e *Env) GetUInt64(key string) uint64 {
+	v, _ := e.GetUInt64E(key)
+	return v
+}
+
+// GetUInt64D attempts to retrieve an uint64 named by key. If the value is not present, def is returned instead.
+func (e *Env) GetUInt64D(key string, def uint64) uint64 {
+	defer supplyDefault(e, key, def)
+	v, err := e.GetUInt64E(key)
+	if err != nil || (e.LegacyDefaults && v == 0) {
+		return useDefault(e, key, def)
+	}
+	return v
+}
+
+// GetUInt64E retrieves an uint64 named by key.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it is not a valid uint64.
+func (e *Env) GetUInt64E(key string) (uint64, error) {
+	s, err := e.lookupE(key, "uint64")
+	if err != nil {
+		return 0, err
+	}
+	v, err := parseUInt64(s)
+	if err != nil {
+		return 0, e.parseError(key, s, "uint64", err)
+	}
+	return v, nil
+}
+
+// GetFloat32 retrieves a float32 named by key.
+// float32(0) is returned if the value does not exist or is not a valid float32.
+func (e *Env) GetFloat32(key string) float32 {
+	v, _ := e.GetFloat32E(key)
+	return v
+}
+
+// GetFloat32D attempts to retrieve a float32 named by key. If the value is not present, def is returned instead.
+func (e *Env) GetFloat32D(key string, def float32) float32 {
+	defer supplyDefault(e, key, def)
+	v, err := e.GetFloat32E(key)
+	if err != nil || (e.LegacyDefaults && v == 0) {
+		return useDefault(e, key, def)
+	}
+	return v
+}
+
+// GetFloat32E retrieves a float32 named by key.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it is not a valid float32.
+func (e *Env) GetFloat32E(key string) (float32, error) {
+	s, err := e.lookupE(key, "float32")
+	if err != nil {
+		return 0, err
+	}
+	v, err := parseFloat32(s)
+	if err != nil {
+		return 0, e.parseError(key, s, "float32", err)
+	}
+	return v, nil
+}
+
+// GetFloat64 retrieves a float64 named by key.
+// float64(0) is returned if the value does not exist or is not a valid float64.
+func (e *Env) GetFloat64(key string) float64 {
+	v, _ := e.GetFloat64E(key)
+	return v
+}
+
+// GetFloat64D attempts to retrieve a float64 named by key. If the value is not present, def is returned instead.
+func (e *Env) GetFloat64D(key string, def float64) float64 {
+	defer supplyDefault(e, key, def)
+	v, err := e.GetFloat64E(key)
+	if err != nil || (e.LegacyDefaults && v == 0) {
+		return useDefault(e, key, def)
+	}
+	return v
+}
+
+// GetFloat64E retrieves a float64 named by key.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it is not a valid float64.
+func (e *Env) GetFloat64E(key string) (float64, error) {
+	s, err := e.lookupE(key, "float64")
+	if err != nil {
+		return 0, err
+	}
+	v, err := parseFloat64(s)
+	if err != nil {
+		return 0, e.parseError(key, s, "float64", err)
+	}
+	return v, nil
+}
+
+// GetBool retrieves a bool named by key.
+// bool(false) is returned if the value does not exist or is not a valid bool.
+func (e *Env) GetBool(key string) bool {
+	b, _ := e.GetBoolE(key)
+	return b
+}
+
+// GetBoolD attempts to retrieve a bool named by key. If the value is not present, def is returned instead.
+func (e *Env) GetBoolD(key string, def bool) bool {
+	defer supplyDefault(e, key, def)
+	b, err := e.GetBoolE(key)
+	if err != nil {
+		return useDefault(e, key, def)
+	}
+	return b
+}
+
+// GetBoolE retrieves a bool named by key.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it is not a valid bool.
+func (e *Env) GetBoolE(key string) (bool, error) {
+	s, err := e.lookupE(key, "bool")
+	if err != nil {
+		return false, err
+	}
+	v, err := parseBool(s)
+	if err != nil {
+		return false, e.parseError(key, s, "bool", err)
+	}
+	return v, nil
+}
+
+// GetDuration retrieves a time.Duration named by key.
+// time.Duration(0) is returned if the value does not exist or is not parsed as a valid time.Duration.
+func (e *Env) GetDuration(key string) time.Duration {
+	d, _ := e.GetDurationE(key)
+	return d
+}
+
+// GetDurationD attempts to retrieve a time.Duration named by key. If the value is not present, def is returned instead.
+func (e *Env) GetDurationD(key string, def time.Duration) time.Duration {
+	defer supplyDefault(e, key, def)
+	d, err := e.GetDurationE(key)
+	if err != nil {
+		return useDefault(e, key, def)
+	}
+	return d
+}
+
+// GetDurationE retrieves a time.Duration named by key.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it is not a valid time.Duration.
+func (e *Env) GetDurationE(key string) (time.Duration, error) {
+	s, err := e.lookupE(key, "time.Duration")
+	if err != nil {
+		return 0, err
+	}
+	v, err := parseDuration(s)
+	if err != nil {
+		return 0, e.parseError(key, s, "time.Duration", err)
+	}
+	return v, nil
+}
+
+func (e *Env) source() Source {
+	if e.Source == nil {
+		return OS
+	}
+	return e.Source
+}
+
+func (e *Env) lookup(key string) (string, bool, error) {
+	v, ok, fromFile, err := e.resolve(key)
+	if e.Tracker != nil {
+		e.Tracker.record(e, key, v, ok, fromFile, err)
+	}
+	return v, ok, err
+}
+
+// resolve retrieves the value named by key. fromFile reports whether it was read from the file named by KEY_FILE.
+func (e *Env) resolve(key string) (v string, ok, fromFile bool, err error) {
+	src := e.source()
+	v, ok = src.Lookup(key)
+	if e.Files {
+		if fv, fok, err := e.lookupFile(key, ok && v != ""); fok || err != nil {
+			return fv, fok, fok, err
+		}
+	}
+	if !ok || !e.Expand {
+		return v, ok, false, nil
+	}
+
+	var file func(string, bool) (string, bool, error)
+	if e.Files {
+		file = e.lookupFile
+	}
+	v, err = expandKey(key, v, e.lookupIndirect, file)
+	if err != nil {
+		return "", false, false, err
+	}
+	return v, true, false, nil
+}
+
+// lookupIndirect retrieves the value named by key while resolving another variable, recording it as read by the
+// Tracker of e.
+func (e *Env) lookupIndirect(key string) (string, bool) {
+	v, ok := e.source().Lookup(key)
+	if ok && e.Tracker != nil {
+		e.Tracker.markRead(key)
+	}
+	return v, ok
+}
+
+// lookupE is like lookup, but returns a *NotSetError naming typ if the value is not present or empty.
+func (e *Env) lookupE(key, typ string) (string, error) {
+	v, ok, err := e.lookup(key)
+	if err != nil {
+		return "", err
+	}
+	if !ok || v == "" {
+		return "", &NotSetError{Key: key, Type: typ}
+	}
+	return v, nil
+}
+
+// Get retrieves the value named by key from Default.
+// Unless the Source of Default is replaced, it is equivalent to os.Getenv.
+func Get(key string) string {
+	return Default.Get(key)
+}
+
+// Lookup retrieves the value named by key from Default and reports whether it is present.
+// Unless the Source of Default is replaced, it is equivalent to os.LookupEnv.
+func Lookup(key string) (string, bool) {
+	return Default.Lookup(key)
+}
+
+// GetD attempts to retrieve a string named by key. If the value is not present, def is returned instead.
+func GetD(key string, def string) string {
+	return Default.GetD(key, def)
+}
+
+// GetString retrieves a string named by key.
+// It is functionally the same as Get.
+func GetString(key string) string {
+	return Default.GetString(key)
+}
+
+// GetStringD attempts to retrieve a string named by key. If the value is not present, def is returned instead.
+// It is functionally the same as GetD.
+func GetStringD(key string, def string) string {
+	return Default.GetStringD(key, def)
+}
+
+// GetStringE retrieves a string named by key.
+// A *NotSetError is returned if the value is not present.
+func GetStringE(key string) (string, error) {
+	return Default.GetStringE(key)
 }
 
 // GetInt retrieves an int named by key.
 // int(0) is returned if the value does not exist or is not a valid int.
 func GetInt(key string) int {
-	return parseInt(Get(key))
+	return Default.GetInt(key)
 }
 
 // GetIntD attempts to retrieve an int named by key. If the value is not present, def is returned instead.
 func GetIntD(key string, def int) int {
-	if v := GetInt(key); v != 0 {
-		return v
-	}
-	return def
+	return Default.GetIntD(key, def)
+}
+
+// GetIntE retrieves an int named by key.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it is not a valid int.
+func GetIntE(key string) (int, error) {
+	return Default.GetIntE(key)
 }
 
 // GetInt8 retrieves an int8 named by key.
 // int8(0) is returned if the value does not exist or is not a valid int8.
 func GetInt8(key string) int8 {
-	return parseInt8(Get(key))
+	return Default.GetInt8(key)
 }
 
 // GetInt8D attempts to retrieve an int8 named by key. If the value is not present, def is returned instead.
 func GetInt8D(key string, def int8) int8 {
-	if v := GetInt8(key); v != 0 {
-		return v
-	}
-	return def
+	return Default.GetInt8D(key, def)
+}
+
+// GetInt8E retrieves an int8 named by key.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it is not a valid int8.
+func GetInt8E(key string) (int8, error) {
+	return Default.GetInt8E(key)
 }
 
 // GetInt16 retrieves an int16 named by key.
 // int16(0) is returned if the value does not exist or is not a valid int16.
 func GetInt16(key string) int16 {
-	return parseInt16(Get(key))
+	return Default.GetInt16(key)
 }
 
 // GetInt16D attempts to retrieve an int16 named by key. If the value is not present, def is returned instead.
 func GetInt16D(key string, def int16) int16 {
-	if v := GetInt16(key); v != 0 {
-		return v
-	}
-	return def
+	return Default.GetInt16D(key, def)
+}
+
+// GetInt16E retrieves an int16 named by key.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it is not a valid int16.
+func GetInt16E(key string) (int16, error) {
+	return Default.GetInt16E(key)
 }
 
 // GetInt32 retrieves an int32 named by key.
 // int32(0) is returned if the value does not exist or is not a valid int32.
 func GetInt32(key string) int32 {
-	return parseInt32(Get(key))
+	return Default.GetInt32(key)
 }
 
 // GetInt32D attempts to retrieve an int32 named by key. If the value is not present, def is returned instead.
 func GetInt32D(key string, def int32) int32 {
-	if v := GetInt32(key); v != 0 {
-		return v
-	}
-	return def
+	return Default.GetInt32D(key, def)
+}
+
+// GetInt32E retrieves an int32 named by key.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it is not a valid int32.
+func GetInt32E(key string) (int32, error) {
+	return Default.GetInt32E(key)
 }
 
 // GetInt64 retrieves an int64 named by key.
 // int64(0) is returned if the value does not exist or is not a valid int64.
 func GetInt64(key string) int64 {
-	return parseInt64(Get(key))
+	return Default.GetInt64(key)
 }
 
 // GetInt64D attempts to retrieve an int64 named by key. If the value is not present, def is returned instead.
 func GetInt64D(key string, def int64) int64 {
-	if v := GetInt64(key); v != 0 {
-		return v
-	}
-	return def
+	return Default.GetInt64D(key, def)
+}
+
+// GetInt64E retrieves an int64 named by key.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it is not a valid int64.
+func GetInt64E(key string) (int64, error) {
+	return Default.GetInt64E(key)
 }
 
 // GetUInt retrieves an uint named by key.
 // uint(0) is returned if the value does not exist or is not a valid uint.
 func GetUInt(key string) uint {
-	return parseUInt(Get(key))
+	return Default.GetUInt(key)
 }
 
 // GetUIntD attempts to retrieve an uint named by key. If the value is not present, def is returned instead.
 func GetUIntD(key string, def uint) uint {
-	if v := GetUInt(key); v != 0 {
-		return v
-	}
-	return def
+	return Default.GetUIntD(key, def)
+}
+
+// GetUIntE retrieves an uint named by key.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it is not a valid uint.
+func GetUIntE(key string) (uint, error) {
+	return Default.GetUIntE(key)
 }
 
 // GetUInt8 retrieves an uint8 named by key.
 // uint8(0) is returned if the value does not exist or is not a valid uint8.
 func GetUInt8(key string) uint8 {
-	return parseUInt8(Get(key))
+	return Default.GetUInt8(key)
 }
 
 // GetUInt8D attempts to retrieve an uint8 named by key. If the value is not present, def is returned instead.
 func GetUInt8D(key string, def uint8) uint8 {
-	if v := GetUInt8(key); v != 0 {
-		return v
-	}
-	return def
+	return Default.GetUInt8D(key, def)
+}
+
+// GetUInt8E retrieves an uint8 named by key.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it is not a valid uint8.
+func GetUInt8E(key string) (uint8, error) {
+	return Default.GetUInt8E(key)
 }
 
 // GetUInt16 retrieves an uint16 named by key.
 // uint16(0) is returned if the value does not exist or is not a valid uint16.
 func GetUInt16(key string) uint16 {
-	return parseUInt16(Get(key))
+	return Default.GetUInt16(key)
 }
 
 // GetUInt16D attempts to retrieve an uint16 named by key. If the value is not present, def is returned instead.
 func GetUInt16D(key string, def uint16) uint16 {
-	if v := GetUInt16(key); v != 0 {
-		return v
-	}
-	return def
+	return Default.GetUInt16D(key, def)
+}
+
+// GetUInt16E retrieves an uint16 named by key.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it is not a valid uint16.
+func GetUInt16E(key string) (uint16, error) {
+	return Default.GetUInt16E(key)
 }
 
 // GetUInt32 retrieves an uint32 named by key.
 // uint32(0) is returned if the value does not exist or is not a valid uint32.
 func GetUInt32(key string) uint32 {
-	return parseUInt32(Get(key))
+	return Default.GetUInt32(key)
 }
 
 // GetUInt32D attempts to retrieve an uint32 named by key. If the value is not present, def is returned instead.
 func GetUInt32D(key string, def uint32) uint32 {
-	if v := GetUInt32(key); v != 0 {
-		return v
-	}
-	return def
+	return Default.GetUInt32D(key, def)
+}
+
+// GetUInt32E retrieves an uint32 named by key.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it is not a valid uint32.
+func GetUInt32E(key string) (uint32, error) {
+	return Default.GetUInt32E(key)
 }
 
 // GetUInt64 retrieves an uint64 named by key.
 // uint64(0) is returned if the value does not exist or is not a valid uint64.
 func GetUInt64(key string) uint64 {
-	return parseUInt64(Get(key))
+	return Default.GetUInt64(key)
 }
 
 // GetUInt64D attempts to retrieve an uint64 named by key. If the value is not present, def is returned instead.
 func GetUInt64D(key string, def uint64) uint64 {
-	if v := GetUInt64(key); v != 0 {
-		return v
-	}
-	return def
+	return Default.GetUInt64D(key, def)
+}
+
+// GetUInt64E retrieves an uint64 named by key.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it is not a valid uint64.
+func GetUInt64E(key string) (uint64, error) {
+	return Default.GetUInt64E(key)
 }
 
 // GetFloat32 retrieves a float32 named by key.
 // float32(0) is returned if the value does not exist or is not a valid float32.
 func GetFloat32(key string) float32 {
-	return parseFloat32(Get(key))
+	return Default.GetFloat32(key)
 }
 
 // GetFloat32D attempts to retrieve a float32 named by key. If the value is not present, def is returned instead.
 func GetFloat32D(key string, def float32) float32 {
-	if v := GetFloat32(key); v != 0 {
-		return v
-	}
-	return def
+	return Default.GetFloat32D(key, def)
+}
+
+// GetFloat32E retrieves a float32 named by key.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it is not a valid float32.
+func GetFloat32E(key string) (float32, error) {
+	return Default.GetFloat32E(key)
 }
 
 // GetFloat64 retrieves a float64 named by key.
 // float64(0) is returned if the value does not exist or is not a valid float64.
 func GetFloat64(key string) float64 {
-	return parseFloat64(Get(key))
+	return Default.GetFloat64(key)
 }
 
 // GetFloat64D attempts to retrieve a float64 named by key. If the value is not present, def is returned instead.
 func GetFloat64D(key string, def float64) float64 {
-	if v := GetFloat64(key); v != 0 {
-		return v
-	}
-	return def
+	return Default.GetFloat64D(key, def)
+}
+
+// GetFloat64E retrieves a float64 named by key.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it is not a valid float64.
+func GetFloat64E(key string) (float64, error) {
+	return Default.GetFloat64E(key)
 }
 
 // GetBool retrieves a bool named by key.
 // bool(false) is returned if the value does not exist or is not a valid bool.
 func GetBool(key string) bool {
-	b, _ := parseBool(Get(key))
-	return b
+	return Default.GetBool(key)
 }
 
 // GetBoolD attempts to retrieve a bool named by key. If the value is not present, def is returned instead.
 func GetBoolD(key string, def bool) bool {
-	b, ok := parseBool(GetStringD(key, fmt.Sprintf("%t", def)))
-	if !ok {
-		return def
-	}
-	return b
+	return Default.GetBoolD(key, def)
+}
+
+// GetBoolE retrieves a bool named by key.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it is not a valid bool.
+func GetBoolE(key string) (bool, error) {
+	return Default.GetBoolE(key)
 }
 
 // GetDuration retrieves a time.Duration named by key.
 // time.Duration(0) is returned if the value does not exist or is not parsed as a valid time.Duration.
 func GetDuration(key string) time.Duration {
-	d, _ := time.ParseDuration(Get(key))
-	return d
+	return Default.GetDuration(key)
 }
 
 // GetDurationD attempts to retrieve a time.Duration named by key. If the value is not present, def is returned instead.
 func GetDurationD(key string, def time.Duration) time.Duration {
-	d, err := time.ParseDuration(Get(key))
-	if err != nil {
-		return def
-	}
-	return d
+	return Default.GetDurationD(key, def)
+}
+
+// GetDurationE retrieves a time.Duration named by key.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it is not a valid time.Duration.
+func GetDurationE(key string) (time.Duration, error) {
+	return Default.GetDurationE(key)
 }
 
-func parseInt(s string) int {
-	i, _ := strconv.Atoi(s)
-	return i
+func parseInt(s string) (int, error) {
+	return strconv.Atoi(s)
 }
 
-func parseInt8(s string) int8 {
-	i, _ := strconv.ParseInt(s, 10, 8)
-	return int8(i)
+func parseInt8(s string) (int8, error) {
+	i, err := strconv.ParseInt(s, 10, 8)
+	return int8(i), err
 }
 
-func parseInt16(s string) int16 {
-	i, _ := strconv.ParseInt(s, 10, 16)
-	return int16(i)
+func parseInt16(s string) (int16, error) {
+	i, err := strconv.ParseInt(s, 10, 16)
+	return int16(i), err
 }
 
-func parseInt32(s string) int32 {
-	i, _ := strconv.ParseInt(s, 10, 32)
-	return int32(i)
+func parseInt32(s string) (int32, error) {
+	i, err := strconv.ParseInt(s, 10, 32)
+	return int32(i), err
 }
 
-func parseInt64(s string) int64 {
-	i, _ := strconv.ParseInt(s, 10, 64)
-	return i
+func parseInt64(s string) (int64, error) {
+	return strconv.ParseInt(s, 10, 64)
 }
 
-func parseUInt(s string) uint {
-	return uint(parseUInt64(s))
+func parseUInt(s string) (uint, error) {
+	i, err := strconv.ParseUint(s, 10, strconv.IntSize)
+	return uint(i), err
 }
 
-func parseUInt8(s string) uint8 {
-	i, _ := strconv.ParseUint(s, 10, 8)
-	return uint8(i)
+func parseUInt8(s string) (uint8, error) {
+	i, err := strconv.ParseUint(s, 10, 8)
+	return uint8(i), err
 }
 
-func parseUInt16(s string) uint16 {
-	i, _ := strconv.ParseUint(s, 10, 16)
-	return uint16(i)
+func parseUInt16(s string) (uint16, error) {
+	i, err := strconv.ParseUint(s, 10, 16)
+	return uint16(i), err
 }
 
-func parseUInt32(s string) uint32 {
-	i, _ := strconv.ParseUint(s, 10, 32)
-	return uint32(i)
+func parseUInt32(s string) (uint32, error) {
+	i, err := strconv.ParseUint(s, 10, 32)
+	return uint32(i), err
 }
 
-func parseUInt64(s string) uint64 {
-	i, _ := strconv.ParseUint(s, 10, 64)
-	return i
+func parseUInt64(s string) (uint64, error) {
+	return strconv.ParseUint(s, 10, 64)
 }
 
-func parseFloat32(s string) float32 {
-	f, _ := strconv.ParseFloat(s, 32)
-	return float32(f)
+func parseFloat32(s string) (float32, error) {
+	f, err := strconv.ParseFloat(s, 32)
+	return float32(f), err
 }
 
-func parseFloat64(s string) float64 {
-	f, _ := strconv.ParseFloat(s, 64)
-	return f
+func parseFloat64(s string) (float64, error) {
+	return strconv.ParseFloat(s, 64)
 }
 
-func parseBool(s string) (bool, bool) {
+func parseBool(s string) (bool, error) {
 	switch strings.ToUpper(s) {
 	case "1", "T", "Y", "TRUE", "YES":
-		return true, true
+		return true, nil
 	case "0", "F", "N", "FALSE", "NO":
-		return false, true
+		return false, nil
 	}
 
-	return false, false
+	return false, strconv.ErrSyntax
+}
+
+func parseDuration(s string) (time.Duration, error) {
+	return time.ParseDuration(s)
 }
@@ -1,16 +1,25 @@
 package env
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"reflect"
 	"runtime"
+	"strconv"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 )
 
+// unsetenv removes key from the process environment, restoring it when t finishes.
+func unsetenv(t *testing.T, key string) {
+	t.Helper()
+	t.Setenv(key, "")
+	require.NoError(t, os.Unsetenv(key))
+}
+
 func TestGet(t *testing.T) {
 	tests := []struct {
 		value string
@@ -213,7 +222,7 @@ func TestGet(t *testing.T) {
 	for i, test := range tests {
 		fnName := runtime.FuncForPC(reflect.ValueOf(test.fn).Pointer()).Name()
 		t.Run(fmt.Sprintf("%d %v", i, fnName), func(t *testing.T) {
-			_ = os.Setenv("FOO", test.value)
+			t.Setenv("FOO", test.value)
 
 			args := []reflect.Value{reflect.ValueOf("FOO")}
 
@@ -280,6 +289,12 @@ func TestGetD(t *testing.T) {
 			fn:       GetIntD,
 			expected: 1,
 		},
+		{
+			value:    "0",
+			def:      1,
+			fn:       GetIntD,
+			expected: 0,
+		},
 		{
 			value:    "",
 			def:      int8(1),
@@ -400,6 +415,18 @@ func TestGetD(t *testing.T) {
 			fn:       GetFloat64D,
 			expected: 1.5,
 		},
+		{
+			value:    "0",
+			def:      1.5,
+			fn:       GetFloat64D,
+			expected: float64(0),
+		},
+		{
+			value:    "0",
+			def:      uint8(1),
+			fn:       GetUInt8D,
+			expected: uint8(0),
+		},
 		{
 			value:    "",
 			def:      false,
@@ -441,7 +468,7 @@ func TestGetD(t *testing.T) {
 	for i, test := range tests {
 		fnName := runtime.FuncForPC(reflect.ValueOf(test.fn).Pointer()).Name()
 		t.Run(fmt.Sprintf("%d %v", i, fnName), func(t *testing.T) {
-			_ = os.Setenv("FOO", test.value)
+			t.Setenv("FOO", test.value)
 
 			args := []reflect.Value{reflect.ValueOf("FOO"), reflect.ValueOf(test.def)}
 
@@ -451,3 +478,164 @@ func TestGetD(t *testing.T) {
 		})
 	}
 }
+
+func TestGetE(t *testing.T) {
+	tests := []struct {
+		value string
+		fn    interface{}
+
+		expected interface{}
+		err      error
+	}{
+		{
+			value:    "BAR",
+			fn:       GetStringE,
+			expected: "BAR",
+		},
+		{
+			value:    "",
+			fn:       GetStringE,
+			expected: "",
+			err:      ErrNotSet,
+		},
+		{
+			value:    "80",
+			fn:       GetIntE,
+			expected: 80,
+		},
+		{
+			value:    "80a",
+			fn:       GetIntE,
+			expected: 0,
+			err:      &ParseError{Key: "FOO", Value: "80a", Type: "int", Err: strconv.ErrSyntax},
+		},
+		{
+			value:    "",
+			fn:       GetIntE,
+			expected: 0,
+			err:      ErrNotSet,
+		},
+		{
+			value:    "300",
+			fn:       GetInt8E,
+			expected: int8(0),
+			err:      &ParseError{Key: "FOO", Value: "300", Type: "int8", Err: strconv.ErrRange},
+		},
+		{
+			value:    "-1",
+			fn:       GetInt16E,
+			expected: int16(-1),
+		},
+		{
+			value:    "-1",
+			fn:       GetInt32E,
+			expected: int32(-1),
+		},
+		{
+			value:    "-1",
+			fn:       GetInt64E,
+			expected: int64(-1),
+		},
+		{
+			value:    "-1",
+			fn:       GetUIntE,
+			expected: uint(0),
+			err:      &ParseError{Key: "FOO", Value: "-1", Type: "uint", Err: strconv.ErrSyntax},
+		},
+		{
+			value:    "1",
+			fn:       GetUInt8E,
+			expected: uint8(1),
+		},
+		{
+			value:    "1",
+			fn:       GetUInt16E,
+			expected: uint16(1),
+		},
+		{
+			value:    "1",
+			fn:       GetUInt32E,
+			expected: uint32(1),
+		},
+		{
+			value:    "1",
+			fn:       GetUInt64E,
+			expected: uint64(1),
+		},
+		{
+			value:    "1.5",
+			fn:       GetFloat32E,
+			expected: float32(1.5),
+		},
+		{
+			value:    "1.5",
+			fn:       GetFloat64E,
+			expected: 1.5,
+		},
+		{
+			value:    "yes",
+			fn:       GetBoolE,
+			expected: true,
+		},
+		{
+			value:    "BAR",
+			fn:       GetBoolE,
+			expected: false,
+			err:      &ParseError{Key: "FOO", Value: "BAR", Type: "bool", Err: strconv.ErrSyntax},
+		},
+		{
+			value:    "1s",
+			fn:       GetDurationE,
+			expected: time.Second,
+		},
+	}
+
+	for i, test := range tests {
+		fnName := runtime.FuncForPC(reflect.ValueOf(test.fn).Pointer()).Name()
+		t.Run(fmt.Sprintf("%d %v", i, fnName), func(t *testing.T) {
+			t.Setenv("FOO", test.value)
+
+			args := []reflect.Value{reflect.ValueOf("FOO")}
+
+			result := reflect.ValueOf(test.fn).Call(args)
+			require.IsType(t, test.expected, result[0].Interface())
+			require.EqualValues(t, test.expected, result[0].Interface())
+
+			err, _ := result[1].Interface().(error)
+			switch expected := test.err.(type) {
+			case nil:
+				require.NoError(t, err)
+			case *ParseError:
+				var parseErr *ParseError
+				require.True(t, errors.As(err, &parseErr))
+				require.Equal(t, expected, parseErr)
+			default:
+				require.True(t, errors.Is(err, expected))
+			}
+		})
+	}
+}
+
+func TestGetDurationE_ParseError(t *testing.T) {
+	t.Setenv("FOO", "100")
+
+	_, err := GetDurationE("FOO")
+
+	var parseErr *ParseError
+	require.True(t, errors.As(err, &parseErr))
+	require.Equal(t, "FOO", parseErr.Key)
+	require.Equal(t, "100", parseErr.Value)
+	require.Equal(t, "time.Duration", parseErr.Type)
+}
+
+func TestGetD_LegacyDefaults(t *testing.T) {
+	Default.LegacyDefaults = true
+	defer func() { Default.LegacyDefaults = false }()
+
+	t.Setenv("FOO", "0")
+	require.Equal(t, 3, GetIntD("FOO", 3))
+	require.Equal(t, uint64(3), GetUInt64D("FOO", 3))
+	require.Equal(t, 1.5, GetFloat64D("FOO", 1.5))
+	require.Equal(t, false, GetBoolD("FOO", true))
+	require.Equal(t, time.Duration(0), GetDurationD("FOO", time.Second))
+}
@@ -0,0 +1,127 @@
+// Package envtest provides helpers for tests reading environment variables.
+//
+// Set, SetPrefix and Unset modify the process environment with t.Setenv, which restores it when the test finishes and
+// panics if the test or an ancestor is parallel. Parallel tests should instead retrieve values from the *env.Env
+// returned by New or Overlay, which never reads or modifies the process environment beyond Overlay's fallback.
+//
+// Route makes an *env.Env returned by New or Overlay the env.Default for the duration of a test, so the package-level
+// getters of env, the methods of env.Prefix and handles declared against env.Default read from it. As env.Default is
+// shared by the whole process, Route, like Set, panics if the test is parallel. Parallel tests must instead pass the
+// *env.Env, or the env.Namespace returned by Prefix, to the code under test as an env.Scope.
+package envtest
+
+import (
+	"os"
+	"sort"
+	"testing"
+
+	"github.com/dmcneil/env"
+)
+
+// Set sets vars in the process environment with t.Setenv, restoring the previous values, or unset state, when t
+// finishes. It panics if t is parallel.
+func Set(t testing.TB, vars map[string]string) {
+	t.Helper()
+
+	for k, v := range vars {
+		t.Setenv(k, v)
+	}
+}
+
+// SetPrefix is like Set, but prefixes the name of each variable by p as when retrieving it from p.
+func SetPrefix(t testing.TB, p env.Prefix, vars map[string]string) {
+	t.Helper()
+
+	prefixed := make(map[string]string, len(vars))
+	for k, v := range vars {
+		prefixed[p.Key(k)] = v
+	}
+	Set(t, prefixed)
+}
+
+// Unset removes keys from the process environment, restoring the previous values when t finishes. Like Set, it panics
+// if t is parallel.
+func Unset(t testing.TB, keys ...string) {
+	t.Helper()
+
+	for _, k := range keys {
+		// t.Setenv registers the restoring cleanup and rejects parallel tests.
+		t.Setenv(k, "")
+		if err := os.Unsetenv(k); err != nil {
+			t.Fatalf("envtest: unset %s: %v", k, err)
+		}
+	}
+}
+
+// routedKey is set with t.Setenv by Route, so that Route panics in parallel tests as t.Setenv does.
+const routedKey = "ENVTEST_ROUTED"
+
+// Route replaces env.Default by e, restoring the previous env.Default when t finishes, so that every getter reading
+// env.Default, including those of env.Prefix, retrieves values from e. It panics if t is parallel.
+//
+//	envtest.Route(t, envtest.New(map[string]string{"PORT": "8080"}))
+//	port := env.GetInt("PORT") // 8080, regardless of the process environment
+func Route(t testing.TB, e *env.Env) {
+	t.Helper()
+
+	t.Setenv(routedKey, "1")
+	prev := env.Default
+	env.Default = e
+	t.Cleanup(func() { env.Default = prev })
+}
+
+// Prefix returns an env.Namespace retrieving the variables prefixed by p from e, like env.Prefix retrieves them from
+// env.Default. Pass it to code under test that accepts an env.Scope.
+//
+//	db := envtest.Prefix(envtest.New(map[string]string{"DB_HOST": "localhost"}), "DB")
+//	host := env.GetAsIn[string](db, "HOST")
+func Prefix(e *env.Env, p env.Prefix) env.Namespace {
+	if p == "" {
+		return env.Namespace{Env: e}
+	}
+	return env.Namespace{Env: e, Path: []string{string(p)}}
+}
+
+// New returns an *env.Env retrieving values only from a copy of vars, isolated from the process environment.
+func New(vars map[string]string) *env.Env {
+	return env.New(copyMap(vars))
+}
+
+// Overlay returns an *env.Env retrieving values from a copy of vars, falling back to the process environment for
+// variables not in vars.
+func Overlay(vars map[string]string) *env.Env {
+	return env.New(&overlay{vars: copyMap(vars), base: env.OS})
+}
+
+func copyMap(vars map[string]string) env.Map {
+	m := make(env.Map, len(vars))
+	for k, v := range vars {
+		m[k] = v
+	}
+	return m
+}
+
+// overlay is a Source returning the values of vars, falling back to base.
+type overlay struct {
+	vars env.Map
+	base env.Source
+}
+
+func (o *overlay) Lookup(key string) (string, bool) {
+	if v, ok := o.vars[key]; ok {
+		return v, true
+	}
+	return o.base.Lookup(key)
+}
+
+func (o *overlay) Keys() []string {
+	keys := o.vars.Keys()
+	base, _ := env.Keys(o.base)
+	for _, k := range base {
+		if _, ok := o.vars[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,112 @@
+package envtest
+
+import (
+	"os"
+	"testing"
+
+	"github.com/dmcneil/env"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSet(t *testing.T) {
+	t.Setenv("ENVTEST_KEPT", "before")
+	Unset(t, "ENVTEST_NEW")
+
+	t.Run("set", func(t *testing.T) {
+		Set(t, map[string]string{"ENVTEST_KEPT": "during", "ENVTEST_NEW": "1"})
+		require.Equal(t, "during", env.Get("ENVTEST_KEPT"))
+		require.Equal(t, 1, env.GetInt("ENVTEST_NEW"))
+	})
+
+	require.Equal(t, "before", os.Getenv("ENVTEST_KEPT"))
+	_, ok := os.LookupEnv("ENVTEST_NEW")
+	require.False(t, ok)
+}
+
+func TestSetPrefix(t *testing.T) {
+	t.Run("set", func(t *testing.T) {
+		SetPrefix(t, "ENVTEST", map[string]string{"PORT": "8080"})
+		require.Equal(t, 8080, env.Prefix("ENVTEST").GetInt("PORT"))
+	})
+
+	_, ok := os.LookupEnv("ENVTEST_PORT")
+	require.False(t, ok)
+}
+
+func TestUnset(t *testing.T) {
+	t.Setenv("ENVTEST_KEPT", "before")
+
+	t.Run("unset", func(t *testing.T) {
+		Unset(t, "ENVTEST_KEPT")
+		_, ok := env.Lookup("ENVTEST_KEPT")
+		require.False(t, ok)
+	})
+
+	require.Equal(t, "before", os.Getenv("ENVTEST_KEPT"))
+}
+
+func TestSet_Parallel(t *testing.T) {
+	t.Run("parallel", func(t *testing.T) {
+		t.Parallel()
+		require.Panics(t, func() { Set(t, map[string]string{"ENVTEST_PARALLEL": "1"}) })
+		require.Panics(t, func() { Unset(t, "ENVTEST_PARALLEL") })
+	})
+}
+
+func TestRoute(t *testing.T) {
+	t.Setenv("ENVTEST_PORT", "1")
+	prev := env.Default
+
+	t.Run("route", func(t *testing.T) {
+		Route(t, New(map[string]string{"ENVTEST_PORT": "8080"}))
+		require.Equal(t, 8080, env.GetInt("ENVTEST_PORT"))
+		require.Equal(t, 8080, env.Prefix("ENVTEST").GetInt("PORT"))
+		require.Equal(t, 8080, env.Int("ENVTEST_PORT").Load())
+	})
+
+	require.Same(t, prev, env.Default)
+	require.Equal(t, 1, env.GetInt("ENVTEST_PORT"))
+
+	t.Run("parallel", func(t *testing.T) {
+		t.Parallel()
+		require.Panics(t, func() { Route(t, New(nil)) })
+	})
+}
+
+func TestPrefix(t *testing.T) {
+	t.Parallel()
+
+	e := New(map[string]string{"ENVTEST_PORT": "8080", "PORT": "1"})
+	require.Equal(t, 8080, env.GetAsIn[int](Prefix(e, "ENVTEST"), "PORT"))
+	require.Equal(t, 1, env.GetAsIn[int](Prefix(e, ""), "PORT"))
+	require.Equal(t, "ENVTEST_PORT", Prefix(e, "ENVTEST").Key("PORT"))
+}
+
+func TestNew(t *testing.T) {
+	for _, port := range []string{"1", "2", "3"} {
+		port := port
+		t.Run(port, func(t *testing.T) {
+			t.Parallel()
+
+			vars := map[string]string{"PORT": port}
+			e := New(vars)
+			vars["PORT"] = "changed"
+			require.Equal(t, port, e.Get("PORT"))
+			require.Equal(t, "", e.Get("PATH"))
+		})
+	}
+}
+
+func TestOverlay(t *testing.T) {
+	t.Setenv("ENVTEST_BASE", "base")
+
+	e := Overlay(map[string]string{"ENVTEST_BASE": "overlay", "ENVTEST_ONLY": "1"})
+	require.Equal(t, "overlay", e.Get("ENVTEST_BASE"))
+	require.Equal(t, "1", e.Get("ENVTEST_ONLY"))
+	require.Equal(t, os.Getenv("PATH"), e.Get("PATH"))
+
+	keys, ok := env.Keys(e.Source)
+	require.True(t, ok)
+	require.Contains(t, keys, "ENVTEST_ONLY")
+	require.Contains(t, keys, "ENVTEST_BASE")
+}
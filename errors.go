@@ -0,0 +1,77 @@
+package env
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// ErrNotSet is matched by the errors returned when a value is not present, so they can be tested with errors.Is.
+var ErrNotSet = errors.New("not set")
+
+// NotSetError records a value that is not present.
+type NotSetError struct {
+	Key  string // the name of the variable
+	Type string // the type the value would have been parsed as, e.g. "int"
+}
+
+func (e *NotSetError) Error() string {
+	if e.Type == "" {
+		return fmt.Sprintf("env: %s: not set", e.Key)
+	}
+	return fmt.Sprintf("env: %s: not set, expected %s", e.Key, e.Type)
+}
+
+// Is reports whether target is ErrNotSet.
+func (e *NotSetError) Is(target error) bool {
+	return target == ErrNotSet
+}
+
+// ParseError records a value that could not be parsed as its target type.
+type ParseError struct {
+	Key   string // the name of the variable
+	Value string // the raw value
+	Type  string // the target type, e.g. "int"
+	Err   error  // the reason parsing failed
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("env: %s: parsing %q as %s: %v", e.Key, e.Value, e.Type, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+func newParseError(key, value, typ string, err error) error {
+	return &ParseError{Key: key, Value: value, Type: typ, Err: unwrapNumError(err)}
+}
+
+// ElementError records an element of a list value, or an entry of a map value, that could not be parsed.
+// It is the Err of the *ParseError returned for the whole value.
+type ElementError struct {
+	Index int    // the 0-based index of the element or entry
+	Value string // the raw element or entry
+	Err   error  // the reason parsing failed
+}
+
+func (e *ElementError) Error() string {
+	return fmt.Sprintf("element %d %q: %v", e.Index, e.Value, e.Err)
+}
+
+func (e *ElementError) Unwrap() error {
+	return e.Err
+}
+
+func newElementError(key, value, typ string, i int, elem string, err error) error {
+	return newParseError(key, value, typ, &ElementError{Index: i, Value: elem, Err: unwrapNumError(err)})
+}
+
+// unwrapNumError returns the underlying error of a *strconv.NumError, which otherwise repeats the value.
+func unwrapNumError(err error) error {
+	var numErr *strconv.NumError
+	if errors.As(err, &numErr) {
+		return numErr.Err
+	}
+	return err
+}
@@ -0,0 +1,217 @@
+package env
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ExpandError records a failure to expand a value.
+type ExpandError struct {
+	Key string // the variable whose value was being expanded, if any
+	Msg string // a description of the error
+}
+
+func (e *ExpandError) Error() string {
+	if e.Key == "" {
+		return "env: " + e.Msg
+	}
+	return fmt.Sprintf("env: %s: %s", e.Key, e.Msg)
+}
+
+// CycleError records a variable whose expansion refers back to itself.
+type CycleError struct {
+	Chain []string // the chain of references, starting and ending with the same variable
+}
+
+func (e *CycleError) Error() string {
+	return "env: reference cycle: " + strings.Join(e.Chain, " -> ")
+}
+
+// Expand replaces references to variables in s with the values retrieved by lookup.
+// Referenced values are themselves expanded, and a *CycleError is returned if a reference refers back to itself.
+//
+// The following forms are supported, where the colon forms also treat an empty value as not present:
+//
+//	$VAR, ${VAR}        the value of VAR, or "" if not present
+//	${VAR:-word}        word if VAR is not present or empty
+//	${VAR-word}         word if VAR is not present
+//	${VAR:?message}     an *ExpandError with message if VAR is not present or empty
+//	${VAR?message}      an *ExpandError with message if VAR is not present
+//	${VAR:+word}        word if VAR is present and not empty, otherwise ""
+//	${VAR+word}         word if VAR is present, otherwise ""
+//	$$                  a literal $
+func Expand(s string, lookup func(key string) (string, bool)) (string, error) {
+	x := &expander{lookup: lookup}
+	return x.expand(s)
+}
+
+// expandKey expands value, the value named by key. If file is not nil, it retrieves referenced values from the files
+// named by their KEY_FILE variables, as by Env.lookupFile.
+func expandKey(key, value string, lookup func(key string) (string, bool), file func(key string, set bool) (string, bool, error)) (string, error) {
+	x := &expander{lookup: lookup, file: file, stack: []string{key}}
+	return x.expand(value)
+}
+
+type expander struct {
+	lookup func(key string) (string, bool)
+	file   func(key string, set bool) (string, bool, error)
+	stack  []string
+}
+
+func (x *expander) expand(s string) (string, error) {
+	if !strings.Contains(s, "$") {
+		return s, nil
+	}
+
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '$' || i+1 == len(s) {
+			sb.WriteByte(s[i])
+			continue
+		}
+
+		switch c := s[i+1]; {
+		case c == '$':
+			sb.WriteByte('$')
+			i++
+		case c == '{':
+			end := matchBrace(s, i+2)
+			if end < 0 {
+				return "", x.errorf("unterminated reference in %q", s)
+			}
+			v, err := x.expandBraced(s[i+2 : end])
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(v)
+			i = end
+		case isNameChar(c, true):
+			j := i + 1
+			for j < len(s) && isNameChar(s[j], false) {
+				j++
+			}
+			v, _, err := x.resolve(s[i+1 : j])
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(v)
+			i = j - 1
+		default:
+			sb.WriteByte('$')
+		}
+	}
+	return sb.String(), nil
+}
+
+func (x *expander) expandBraced(ref string) (string, error) {
+	n := 0
+	for n < len(ref) && isNameChar(ref[n], n == 0) {
+		n++
+	}
+	name, rest := ref[:n], ref[n:]
+	if name == "" {
+		return "", x.errorf("invalid reference ${%s}", ref)
+	}
+
+	v, ok, err := x.resolve(name)
+	if err != nil {
+		return "", err
+	}
+	if rest == "" {
+		return v, nil
+	}
+
+	colon := strings.HasPrefix(rest, ":")
+	if colon {
+		rest = rest[1:]
+	}
+	if rest == "" {
+		return "", x.errorf("invalid reference ${%s}", ref)
+	}
+	op, word := rest[0], rest[1:]
+	set := ok && (!colon || v != "")
+
+	switch op {
+	case '-':
+		if set {
+			return v, nil
+		}
+		return x.expand(word)
+	case '+':
+		if !set {
+			return "", nil
+		}
+		return x.expand(word)
+	case '?':
+		if set {
+			return v, nil
+		}
+		msg, err := x.expand(word)
+		if err != nil {
+			return "", err
+		}
+		if msg == "" {
+			msg = "not set"
+		}
+		return "", x.errorf("%s: %s", name, msg)
+	}
+	return "", x.errorf("invalid reference ${%s}", ref)
+}
+
+// resolve retrieves and expands the value named by key. Values read from files are not expanded.
+func (x *expander) resolve(key string) (string, bool, error) {
+	for i, k := range x.stack {
+		if k == key {
+			chain := append(append([]string(nil), x.stack[i:]...), key)
+			return "", false, &CycleError{Chain: chain}
+		}
+	}
+
+	v, ok := x.lookup(key)
+	if x.file != nil {
+		fv, fok, err := x.file(key, ok && v != "")
+		if err != nil {
+			return "", false, err
+		}
+		if fok {
+			return fv, true, nil
+		}
+	}
+	if !ok {
+		return "", false, nil
+	}
+
+	x.stack = append(x.stack, key)
+	v, err := x.expand(v)
+	x.stack = x.stack[:len(x.stack)-1]
+	return v, true, err
+}
+
+func (x *expander) errorf(format string, args ...interface{}) error {
+	var key string
+	if len(x.stack) > 0 {
+		key = x.stack[0]
+	}
+	return &ExpandError{Key: key, Msg: fmt.Sprintf(format, args...)}
+}
+
+// matchBrace returns the index of the brace closing the reference whose contents begin at start, or -1.
+func matchBrace(s string, start int) int {
+	depth := 1
+	for i := start; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func isNameChar(c byte, first bool) bool {
+	return c != '.' && isKeyChar(c, first)
+}
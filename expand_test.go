@@ -0,0 +1,108 @@
+package env
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpand(t *testing.T) {
+	src := Map{
+		"HOST":  "db.local",
+		"PORT":  "5432",
+		"EMPTY": "",
+		"URL":   "postgres://${HOST}:$PORT/app",
+	}
+
+	tests := []struct {
+		s        string
+		expected string
+	}{
+		{s: "plain", expected: "plain"},
+		{s: "$HOST", expected: "db.local"},
+		{s: "${HOST}", expected: "db.local"},
+		{s: "$HOST:$PORT", expected: "db.local:5432"},
+		{s: "$MISSING", expected: ""},
+		{s: "${MISSING:-localhost}", expected: "localhost"},
+		{s: "${EMPTY:-localhost}", expected: "localhost"},
+		{s: "${EMPTY-localhost}", expected: ""},
+		{s: "${MISSING-localhost}", expected: "localhost"},
+		{s: "${MISSING:-${HOST}}", expected: "db.local"},
+		{s: "${HOST:+set}", expected: "set"},
+		{s: "${EMPTY:+set}", expected: ""},
+		{s: "${EMPTY+set}", expected: "set"},
+		{s: "${MISSING+set}", expected: ""},
+		{s: "${HOST:?required}", expected: "db.local"},
+		{s: "$$HOST", expected: "$HOST"},
+		{s: "cost: 5$", expected: "cost: 5$"},
+		{s: "$1", expected: "$1"},
+		{s: "$URL", expected: "postgres://db.local:5432/app"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.s, func(t *testing.T) {
+			v, err := Expand(test.s, src.Lookup)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, v)
+		})
+	}
+}
+
+func TestExpand_Error(t *testing.T) {
+	src := Map{"EMPTY": ""}
+
+	tests := []struct {
+		s   string
+		err string
+	}{
+		{s: "${MISSING:?must be set}", err: "env: MISSING: must be set"},
+		{s: "${EMPTY:?}", err: "env: EMPTY: not set"},
+		{s: "${HOST", err: `env: unterminated reference in "${HOST"`},
+		{s: "${}", err: "env: invalid reference ${}"},
+		{s: "${HOST:}", err: "env: invalid reference ${HOST:}"},
+		{s: "${HOST/x}", err: "env: invalid reference ${HOST/x}"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.s, func(t *testing.T) {
+			_, err := Expand(test.s, src.Lookup)
+
+			var expandErr *ExpandError
+			require.True(t, errors.As(err, &expandErr))
+			require.EqualError(t, err, test.err)
+		})
+	}
+}
+
+func TestEnv_Expand(t *testing.T) {
+	e := New(Map{
+		"DB_HOST":      "",
+		"DB_PORT":      "5432",
+		"DATABASE_URL": "postgres://${DB_HOST:-localhost}:${DB_PORT}/app",
+		"A":            "${B}",
+		"B":            "x${C}",
+		"C":            "$A",
+		"REQUIRED":     "${MISSING:?is required}",
+		"APP_PORT":     "${DB_PORT}",
+	})
+
+	require.Equal(t, "postgres://${DB_HOST:-localhost}:${DB_PORT}/app", e.Get("DATABASE_URL"))
+
+	e.Expand = true
+	require.Equal(t, "postgres://localhost:5432/app", e.Get("DATABASE_URL"))
+	require.Equal(t, 5432, e.GetInt("APP_PORT"))
+
+	_, err := e.GetStringE("A")
+	var cycleErr *CycleError
+	require.True(t, errors.As(err, &cycleErr))
+	require.Equal(t, []string{"A", "B", "C", "A"}, cycleErr.Chain)
+	require.EqualError(t, err, "env: reference cycle: A -> B -> C -> A")
+
+	_, ok := e.Lookup("A")
+	require.False(t, ok)
+
+	_, err = e.GetStringE("REQUIRED")
+	require.EqualError(t, err, "env: REQUIRED: MISSING: is required")
+	require.Equal(t, "fallback", e.GetD("REQUIRED", "fallback"))
+}
@@ -0,0 +1,60 @@
+package env
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+const (
+	// FileSuffix is appended to the name of a variable to name the variable holding the path of its file when Files
+	// is enabled on an Env.
+	FileSuffix = "_FILE"
+
+	// DefaultMaxFileSize is the MaxFileSize used by an Env that does not set one.
+	DefaultMaxFileSize = 64 << 10
+)
+
+// lookupFile retrieves the value named by key from the file named by the value of key+FileSuffix.
+// set reports whether key itself is present, in which case the file must not also be named.
+// The contents of the file are not expanded.
+func (e *Env) lookupFile(key string, set bool) (string, bool, error) {
+	fileKey := key + FileSuffix
+	path, ok := e.lookupIndirect(fileKey)
+	if !ok || path == "" {
+		return "", false, nil
+	}
+	if set {
+		return "", false, fmt.Errorf("env: %s: both %s and %s are set", key, key, fileKey)
+	}
+
+	b, err := e.readFile(path)
+	if err != nil {
+		return "", false, fmt.Errorf("env: %s: %w", fileKey, err)
+	}
+	v := strings.TrimSuffix(string(b), "\n")
+	return strings.TrimSuffix(v, "\r"), true, nil
+}
+
+func (e *Env) readFile(path string) ([]byte, error) {
+	max := e.MaxFileSize
+	if max <= 0 {
+		max = DefaultMaxFileSize
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	b, err := io.ReadAll(io.LimitReader(f, max+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(b)) > max {
+		return nil, fmt.Errorf("%s exceeds the maximum size of %d bytes", path, max)
+	}
+	return b, nil
+}
@@ -0,0 +1,68 @@
+package env
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnv_Files(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+		return path
+	}
+
+	e := New(Map{
+		"PASSWORD_FILE": write("password", "s3cr3t\n"),
+		"TIMEOUT_FILE":  write("timeout", "5s\r\n"),
+		"TOKEN_FILE":    write("token", "$NOPE"),
+		"BOTH":          "value",
+		"BOTH_FILE":     write("both", "file"),
+		"EMPTY":         "",
+		"EMPTY_FILE":    write("empty", "in file"),
+		"MISSING_FILE":  filepath.Join(dir, "missing"),
+		"LARGE_FILE":    write("large", strings.Repeat("x", 16)),
+		"PLAIN":         "plain",
+		"DSN":           "app:${PASSWORD}@${PLAIN}",
+		"TOKEN_REF":     "${TOKEN}",
+		"BOTH_REF":      "${BOTH:-default}",
+	})
+
+	require.Equal(t, "", e.Get("PASSWORD"))
+
+	e.Files = true
+	e.Expand = true
+	e.MaxFileSize = 8
+	require.Equal(t, "s3cr3t", e.Get("PASSWORD"))
+	require.Equal(t, 5*time.Second, e.GetDuration("TIMEOUT"))
+	require.Equal(t, "$NOPE", e.Get("TOKEN"))
+	require.Equal(t, "in file", e.Get("EMPTY"))
+	require.Equal(t, "plain", e.Get("PLAIN"))
+	require.Equal(t, "app:s3cr3t@plain", e.Get("DSN"))
+	require.Equal(t, "$NOPE", e.Get("TOKEN_REF"))
+
+	_, err := e.GetStringE("BOTH")
+	require.EqualError(t, err, "env: BOTH: both BOTH and BOTH_FILE are set")
+
+	_, err = e.GetStringE("BOTH_REF")
+	require.EqualError(t, err, "env: BOTH: both BOTH and BOTH_FILE are set")
+
+	_, err = e.GetStringE("MISSING")
+	require.Error(t, err)
+	require.True(t, strings.HasPrefix(err.Error(), "env: MISSING_FILE: open "))
+	require.True(t, errors.Is(err, fs.ErrNotExist))
+
+	_, err = e.GetStringE("LARGE")
+	require.EqualError(t, err, "env: LARGE_FILE: "+filepath.Join(dir, "large")+" exceeds the maximum size of 8 bytes")
+
+	_, ok := e.Lookup("LARGE")
+	require.False(t, ok)
+}
@@ -0,0 +1,87 @@
+package env
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Scope selects where the generic getters, e.g. ParseIn, retrieve values from.
+// It is implemented by *Env, Prefix and Namespace.
+type Scope interface {
+	// scope returns the Env and the name of the variable to retrieve for key.
+	scope(key string) (*Env, string)
+}
+
+func (e *Env) scope(key string) (*Env, string) {
+	return e, key
+}
+
+func (p Prefix) scope(key string) (*Env, string) {
+	return Default, p.format(key)
+}
+
+// GetAs retrieves a T named by key.
+// The zero value of T is returned if the value does not exist, is not a valid T or violates any of rules.
+func GetAs[T any](key string, rules ...Rule[T]) T {
+	return GetAsIn(Default, key, rules...)
+}
+
+// GetOr attempts to retrieve a T named by key.
+// If the value is not present, is not a valid T or violates any of rules, def is returned instead.
+func GetOr[T any](key string, def T, rules ...Rule[T]) T {
+	return GetOrIn(Default, key, def, rules...)
+}
+
+// Parse retrieves a T named by key.
+// A *NotSetError is returned if the value is not present, a *ParseError if it is not a valid T, or a
+// *ValidationError if it violates any of rules.
+//
+// T may be a string, bool, time.Duration or any integer or floating-point type, including named types such as
+// type Port uint16. Slices of these types are split on the ListSeparator, and maps with string keys are split on the
+// MapSeparator and KeyValueSeparator.
+func Parse[T any](key string, rules ...Rule[T]) (T, error) {
+	return ParseIn(Default, key, rules...)
+}
+
+// GetAsIn retrieves a T named by key from s.
+// The zero value of T is returned if the value does not exist, is not a valid T or violates any of rules.
+func GetAsIn[T any](s Scope, key string, rules ...Rule[T]) T {
+	v, _ := ParseIn(s, key, rules...)
+	return v
+}
+
+// GetOrIn attempts to retrieve a T named by key from s.
+// If the value is not present, is not a valid T or violates any of rules, def is returned instead.
+func GetOrIn[T any](s Scope, key string, def T, rules ...Rule[T]) T {
+	e, name := s.scope(key)
+	defer supplyDefault(e, name, def)
+
+	v, err := ParseIn(s, key, rules...)
+	if err != nil {
+		return useDefault(e, name, def)
+	}
+	return v
+}
+
+// ParseIn retrieves a T named by key from s.
+// See Parse for the supported types and the errors returned.
+func ParseIn[T any](s Scope, key string, rules ...Rule[T]) (T, error) {
+	var zero T
+	e, key := s.scope(key)
+	t := reflect.TypeOf(&zero).Elem()
+	raw, err := e.lookupE(key, t.String())
+	if err != nil {
+		return zero, err
+	}
+
+	v, err := e.parse(t, raw)
+	if err == errUnsupportedType {
+		return zero, fmt.Errorf("env: %s: unsupported type %s", key, t)
+	} else if err != nil {
+		return zero, e.parseError(key, raw, t.String(), err)
+	}
+	if err := validate(key, raw, v.Interface().(T), rules); err != nil {
+		return zero, e.failed(key, err)
+	}
+	return v.Interface().(T), nil
+}
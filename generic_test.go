@@ -0,0 +1,79 @@
+package env
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type genericPort uint16
+
+func TestParse(t *testing.T) {
+	t.Setenv("FOO", "80")
+
+	i, err := Parse[int]("FOO")
+	require.NoError(t, err)
+	require.Equal(t, 80, i)
+
+	u8, err := Parse[uint8]("FOO")
+	require.NoError(t, err)
+	require.Equal(t, uint8(80), u8)
+
+	port, err := Parse[genericPort]("FOO")
+	require.NoError(t, err)
+	require.Equal(t, genericPort(80), port)
+
+	s, err := Parse[string]("FOO")
+	require.NoError(t, err)
+	require.Equal(t, "80", s)
+
+	t.Setenv("FOO", "80a")
+	_, err = Parse[int]("FOO")
+	require.Equal(t, &ParseError{Key: "FOO", Value: "80a", Type: "int", Err: strconv.ErrSyntax}, err)
+
+	unsetenv(t, "FOO")
+	_, err = Parse[time.Duration]("FOO")
+	require.True(t, errors.Is(err, ErrNotSet))
+
+	t.Setenv("FOO", "1")
+	_, err = Parse[complex128]("FOO")
+	require.EqualError(t, err, "env: FOO: unsupported type complex128")
+}
+
+func TestGetAs(t *testing.T) {
+	t.Setenv("FOO", "5s")
+	require.Equal(t, 5*time.Second, GetAs[time.Duration]("FOO"))
+	require.Equal(t, 0, GetAs[int]("FOO"))
+	require.Equal(t, 3, GetOr("FOO", 3))
+	require.Equal(t, 5*time.Second, GetOr("FOO", time.Second))
+
+	t.Setenv("FOO", "0")
+	require.Equal(t, 0, GetOr("FOO", 3))
+
+	unsetenv(t, "FOO")
+	require.Equal(t, 3.5, GetOr("FOO", 3.5))
+}
+
+func TestParseIn(t *testing.T) {
+	e := New(Map{
+		"PORTS":   "80, 443",
+		"PORTS8":  "80,300",
+		"WEIGHTS": "a:0.5,b:0.25",
+		"DEBUG":   "yes",
+	})
+
+	require.Equal(t, []genericPort{80, 443}, GetAsIn[[]genericPort](e, "PORTS"))
+	require.Equal(t, map[string]float32{"a": 0.5, "b": 0.25}, GetAsIn[map[string]float32](e, "WEIGHTS"))
+	require.Equal(t, true, GetOrIn(e, "DEBUG", false))
+
+	_, err := ParseIn[[]int8](e, "PORTS8")
+	var elemErr *ElementError
+	require.True(t, errors.As(err, &elemErr))
+	require.Equal(t, &ElementError{Index: 1, Value: "300", Err: strconv.ErrRange}, elemErr)
+
+	t.Setenv("FOO_PORT", "8080")
+	require.Equal(t, uint16(8080), GetAsIn[uint16](Prefix("FOO"), "PORT"))
+}
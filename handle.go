@@ -0,0 +1,188 @@
+package env
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Handle is a declared variable whose value is parsed once and cached, so it can be read cheaply and concurrently on
+// every request. Its value is loaded on the first call to Load, and only changes when the Handle is refreshed, e.g.
+// after a Watcher reloads.
+//
+//	var timeout = env.Duration("TIMEOUT").Default(5 * time.Second)
+//
+//	ctx, cancel := context.WithTimeout(ctx, timeout.Load())
+//
+// Handle is not named Var, as Var describes a variable in a Registry.
+type Handle[T any] struct {
+	scope Scope // Default, when the Handle is loaded, if nil
+	key   string
+	def   *T
+	rules []Rule[T]
+
+	value atomic.Pointer[handleValue[T]]
+}
+
+type handleValue[T any] struct {
+	v   T
+	err error
+}
+
+// NewHandle returns a Handle retrieving a T named by key from Default. Default is resolved each time the Handle is
+// loaded, so replacing it, e.g. in tests, takes effect on the next load or refresh.
+func NewHandle[T any](key string) *Handle[T] {
+	return &Handle[T]{key: key}
+}
+
+// NewHandleIn returns a Handle retrieving a T named by key from s.
+func NewHandleIn[T any](s Scope, key string) *Handle[T] {
+	return &Handle[T]{scope: s, key: key}
+}
+
+// String returns a Handle retrieving a string named by key from Default.
+func String(key string) *Handle[string] { return NewHandle[string](key) }
+
+// Int returns a Handle retrieving an int named by key from Default.
+func Int(key string) *Handle[int] { return NewHandle[int](key) }
+
+// Int64 returns a Handle retrieving an int64 named by key from Default.
+func Int64(key string) *Handle[int64] { return NewHandle[int64](key) }
+
+// UInt returns a Handle retrieving a uint named by key from Default.
+func UInt(key string) *Handle[uint] { return NewHandle[uint](key) }
+
+// UInt64 returns a Handle retrieving a uint64 named by key from Default.
+func UInt64(key string) *Handle[uint64] { return NewHandle[uint64](key) }
+
+// Float64 returns a Handle retrieving a float64 named by key from Default.
+func Float64(key string) *Handle[float64] { return NewHandle[float64](key) }
+
+// Bool returns a Handle retrieving a bool named by key from Default.
+func Bool(key string) *Handle[bool] { return NewHandle[bool](key) }
+
+// Duration returns a Handle retrieving a time.Duration named by key from Default.
+func Duration(key string) *Handle[time.Duration] { return NewHandle[time.Duration](key) }
+
+// Default sets the value used when the variable is not present, and returns h.
+// Default and Validate are intended to be called when h is declared, and discard any loaded value.
+func (h *Handle[T]) Default(def T) *Handle[T] {
+	h.def = &def
+	h.value.Store(nil)
+	return h
+}
+
+// Validate adds rules the value must satisfy, and returns h.
+func (h *Handle[T]) Validate(rules ...Rule[T]) *Handle[T] {
+	h.rules = append(h.rules, rules...)
+	h.value.Store(nil)
+	return h
+}
+
+// Key returns the name of the variable retrieved by h.
+func (h *Handle[T]) Key() string {
+	return h.key
+}
+
+// Load returns the cached value of h, loading it if this is the first call.
+// If the value could not be loaded, the default, or the zero value of T if there is none, is returned and the error
+// is reported by Err.
+func (h *Handle[T]) Load() T {
+	return h.load().v
+}
+
+// Err returns the error of the first load of h, or nil if it succeeded or a later Refresh did.
+func (h *Handle[T]) Err() error {
+	return h.load().err
+}
+
+// Refresh reloads the value of h. See the package function Refresh.
+func (h *Handle[T]) Refresh() error {
+	return Refresh(h)
+}
+
+func (h *Handle[T]) load() *handleValue[T] {
+	if hv := h.value.Load(); hv != nil {
+		return hv
+	}
+
+	v, err := h.parse()
+	if err != nil && h.def != nil {
+		e, key := h.getScope().scope(h.key)
+		v = useDefault(e, key, *h.def)
+	}
+	h.value.CompareAndSwap(nil, &handleValue[T]{v: v, err: err})
+	return h.value.Load()
+}
+
+func (h *Handle[T]) parse() (T, error) {
+	v, err := ParseIn(h.getScope(), h.key, h.rules...)
+	if errors.Is(err, ErrNotSet) && h.def != nil {
+		e, key := h.getScope().scope(h.key)
+		return useDefault(e, key, *h.def), nil
+	}
+	return v, err
+}
+
+func (h *Handle[T]) getScope() Scope {
+	if h.scope == nil {
+		return Default
+	}
+	return h.scope
+}
+
+func (h *Handle[T]) prepare() (func(), error) {
+	v, err := h.parse()
+	if err != nil {
+		return nil, err
+	}
+	return func() { h.value.Store(&handleValue[T]{v: v}) }, nil
+}
+
+// Refresher is a value that is reloaded by Refresh. It is implemented by *Handle.
+type Refresher interface {
+	// prepare parses the new value, and returns a function storing it.
+	prepare() (store func(), err error)
+}
+
+// refreshMu is held for writing while Refresh stores values, and for reading by View.
+var refreshMu sync.RWMutex
+
+// Refresh reloads the values of handles as one batch. Every value is parsed and validated before any is stored, and if
+// any fails, a *CheckError listing every failure is returned and every handle keeps its previous value.
+// Use View to read several handles without observing a batch being stored.
+func Refresh(handles ...Refresher) error {
+	var (
+		stores []func()
+		errs   []error
+	)
+	for _, h := range handles {
+		store, err := h.prepare()
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		stores = append(stores, store)
+	}
+	if len(errs) > 0 {
+		return &CheckError{Errs: errs}
+	}
+
+	refreshMu.Lock()
+	defer refreshMu.Unlock()
+
+	for _, store := range stores {
+		store()
+	}
+	return nil
+}
+
+// View calls fn, during which no batch of Refresh is stored, so the handles loaded by fn are consistent with each other.
+// fn must not call Refresh.
+func View(fn func()) {
+	refreshMu.RLock()
+	defer refreshMu.RUnlock()
+
+	fn()
+}
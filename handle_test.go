@@ -0,0 +1,125 @@
+package env
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandle(t *testing.T) {
+	m := Map{"TIMEOUT": "10s", "APP_PORT": "8080"}
+	e := New(m)
+
+	timeout := NewHandleIn[time.Duration](e, "TIMEOUT").Default(5 * time.Second)
+	retries := NewHandleIn[int](e, "RETRIES").Default(3)
+	port := NewHandleIn[uint16](e, "APP_PORT").Validate(Min[uint16](1))
+
+	require.Equal(t, 10*time.Second, timeout.Load())
+	require.Equal(t, 3, retries.Load())
+	require.Equal(t, uint16(8080), port.Load())
+	require.NoError(t, port.Err())
+	require.Equal(t, "TIMEOUT", timeout.Key())
+
+	m["TIMEOUT"] = "20s"
+	require.Equal(t, 10*time.Second, timeout.Load())
+	require.NoError(t, timeout.Refresh())
+	require.Equal(t, 20*time.Second, timeout.Load())
+}
+
+func TestHandle_Err(t *testing.T) {
+	e := New(Map{"TIMEOUT": "soon"})
+
+	timeout := NewHandleIn[time.Duration](e, "TIMEOUT").Default(5 * time.Second)
+	require.Equal(t, 5*time.Second, timeout.Load())
+	var perr *ParseError
+	require.True(t, errors.As(timeout.Err(), &perr))
+
+	missing := NewHandleIn[int](e, "MISSING")
+	require.Equal(t, 0, missing.Load())
+	require.True(t, errors.Is(missing.Err(), ErrNotSet))
+}
+
+func TestRefresh(t *testing.T) {
+	m := Map{"HOST": "a", "PORT": "1"}
+	e := New(m)
+	host := NewHandleIn[string](e, "HOST")
+	port := NewHandleIn[int](e, "PORT")
+	require.Equal(t, "a", host.Load())
+	require.Equal(t, 1, port.Load())
+
+	m["HOST"], m["PORT"] = "b", "invalid"
+	err := Refresh(host, port)
+	var cerr *CheckError
+	require.True(t, errors.As(err, &cerr))
+	require.Len(t, cerr.Errs, 1)
+	require.Equal(t, "a", host.Load())
+	require.Equal(t, 1, port.Load())
+
+	m["PORT"] = "2"
+	require.NoError(t, Refresh(host, port))
+	require.Equal(t, "b", host.Load())
+	require.Equal(t, 2, port.Load())
+	require.NoError(t, port.Err())
+}
+
+func TestView(t *testing.T) {
+	var n int
+	w, err := NewWatcher(func() (Map, error) {
+		v := strconv.Itoa(n)
+		return Map{"A": v, "B": v}, nil
+	})
+	require.NoError(t, err)
+	e := New(w)
+	a, b := NewHandleIn[int](e, "A"), NewHandleIn[int](e, "B")
+	require.Equal(t, 0, a.Load())
+	require.Equal(t, 0, b.Load())
+
+	errc := make(chan error, 1)
+	go func() {
+		for n = 1; n <= 100; n++ {
+			if err := w.Reload(); err != nil {
+				errc <- err
+				return
+			}
+			if err := Refresh(a, b); err != nil {
+				errc <- err
+				return
+			}
+		}
+		errc <- nil
+	}()
+
+	for i := 0; i < 100; i++ {
+		View(func() {
+			require.Equal(t, a.Load(), b.Load())
+		})
+	}
+	require.NoError(t, <-errc)
+}
+
+func TestHandle_Constructors(t *testing.T) {
+	const key = "HANDLE_CONSTRUCTORS"
+	unsetenv(t, key)
+
+	require.Equal(t, key, String(key).Key())
+	require.Equal(t, 3, Int(key).Default(3).Load())
+	require.Equal(t, int64(3), Int64(key).Default(3).Load())
+	require.Equal(t, uint(3), UInt(key).Default(3).Load())
+	require.Equal(t, uint64(3), UInt64(key).Default(3).Load())
+	require.Equal(t, 1.5, Float64(key).Default(1.5).Load())
+	require.Equal(t, true, Bool(key).Default(true).Load())
+	require.Equal(t, time.Second, Duration(key).Default(time.Second).Load())
+}
+
+func TestNewHandle_Default(t *testing.T) {
+	timeout := Duration("HANDLE_TIMEOUT").Default(time.Second)
+
+	d := Default
+	defer func() { Default = d }()
+	Default = New(Map{"HANDLE_TIMEOUT": "5s"})
+
+	require.Equal(t, 5*time.Second, timeout.Load())
+}
@@ -0,0 +1,132 @@
+package env
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// UnknownKeyError records a variable under a Prefix that is not one of the known names, e.g. a misspelling.
+type UnknownKeyError struct {
+	Key        string // the name of the variable
+	Suggestion string // the name of the closest known variable, if any is close enough to be a likely misspelling
+}
+
+func (e *UnknownKeyError) Error() string {
+	if e.Suggestion == "" {
+		return fmt.Sprintf("env: %s: unknown variable", e.Key)
+	}
+	return fmt.Sprintf("env: %s: unknown variable, did you mean %s?", e.Key, e.Suggestion)
+}
+
+// PrefixKeys returns the names of the variables with the prefix p, with the prefix removed, in sorted order.
+// It returns nil if the Source does not implement Enumerator.
+func (e *Env) PrefixKeys(p Prefix) []string {
+	keys, _ := Keys(e.source())
+	prefix := p.format("")
+	var names []string
+	for _, k := range keys {
+		if strings.HasPrefix(k, prefix) && len(k) > len(prefix) {
+			names = append(names, k[len(prefix):])
+		}
+	}
+	return names
+}
+
+// PrefixMap returns the values of the variables with the prefix p, keyed by their names with the prefix removed.
+// Variables that are not present, such as those whose expansion fails, are omitted.
+func (e *Env) PrefixMap(p Prefix) map[string]string {
+	m := map[string]string{}
+	for _, name := range e.PrefixKeys(p) {
+		if v, ok := e.Lookup(p.format(name)); ok {
+			m[name] = v
+		}
+	}
+	return m
+}
+
+// CheckPrefix reports the variables with the prefix p whose names, with the prefix removed, are not one of known.
+// A *CheckError listing an *UnknownKeyError for each is returned, suggesting the closest known name where one is
+// likely to have been misspelled. If Files is enabled, KEY_FILE is known for each known KEY.
+func (e *Env) CheckPrefix(p Prefix, known ...string) error {
+	set := make(map[string]bool, len(known))
+	for _, k := range known {
+		set[k] = true
+	}
+
+	var errs []error
+	for _, name := range e.PrefixKeys(p) {
+		if set[name] || (e.Files && set[strings.TrimSuffix(name, FileSuffix)]) {
+			continue
+		}
+		err := &UnknownKeyError{Key: p.format(name)}
+		if s, ok := suggest(name, known); ok {
+			err.Suggestion = p.format(s)
+		}
+		errs = append(errs, err)
+	}
+	if len(errs) > 0 {
+		return &CheckError{Errs: errs}
+	}
+	return nil
+}
+
+// ParsePrefix retrieves each variable with the prefix p from Default as a T, keyed by its name with the prefix
+// removed. See ParsePrefixIn for details.
+func ParsePrefix[T any](p Prefix, rules ...Rule[T]) (map[string]T, error) {
+	return ParsePrefixIn(Default, p, rules...)
+}
+
+// ParsePrefixIn retrieves each variable with the prefix p from e as a T, keyed by its name with the prefix removed.
+// Empty values are omitted. If any value is not a valid T or violates any of rules, a *CheckError listing every
+// failure is returned along with the values that were valid.
+func ParsePrefixIn[T any](e *Env, p Prefix, rules ...Rule[T]) (map[string]T, error) {
+	m := map[string]T{}
+	var errs []error
+	for _, name := range e.PrefixKeys(p) {
+		v, err := ParseIn(e, p.format(name), rules...)
+		if err == nil {
+			m[name] = v
+		} else if !errors.Is(err, ErrNotSet) {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return m, &CheckError{Errs: errs}
+	}
+	return m, nil
+}
+
+// suggest returns the name in known closest to name, if it is close enough to be a likely misspelling.
+func suggest(name string, known []string) (string, bool) {
+	best, bestDist := "", -1
+	for _, k := range known {
+		d := distance(strings.ToUpper(name), strings.ToUpper(k))
+		if bestDist < 0 || d < bestDist {
+			best, bestDist = k, d
+		}
+	}
+	return best, bestDist >= 0 && bestDist <= max(1, len(name)/3)
+}
+
+// distance returns the Levenshtein distance between a and b.
+func distance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
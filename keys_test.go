@@ -0,0 +1,88 @@
+package env
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnv_PrefixKeys(t *testing.T) {
+	e := New(Map{
+		"FEATURE_X":    "true",
+		"FEATURE_Y":    "false",
+		"FEATURE_":     "ignored",
+		"FEATURES":     "ignored",
+		"OTHER_FOO":    "ignored",
+		"FEATURE_SIZE": "10",
+	})
+
+	require.Equal(t, []string{"SIZE", "X", "Y"}, e.PrefixKeys("FEATURE"))
+	require.Equal(t, map[string]string{"SIZE": "10", "X": "true", "Y": "false"}, e.PrefixMap("FEATURE"))
+	require.Nil(t, New(SourceFunc(func(string) (string, bool) { return "", false })).PrefixKeys("FEATURE"))
+}
+
+func TestParsePrefixIn(t *testing.T) {
+	e := New(Map{"LIMIT_A": "1", "LIMIT_B": "2", "LIMIT_C": "", "LIMIT_D": "x"})
+
+	m, err := ParsePrefixIn[int](e, "LIMIT")
+	require.Equal(t, map[string]int{"A": 1, "B": 2}, m)
+	var perr *ParseError
+	require.True(t, errors.As(err, &perr))
+	require.Equal(t, "LIMIT_D", perr.Key)
+
+	e = New(Map{"LIMIT_A": "1", "LIMIT_B": "2"})
+	m, err = ParsePrefixIn(e, "LIMIT", Max(1))
+	require.Equal(t, map[string]int{"A": 1}, m)
+	var verr *ValidationError
+	require.True(t, errors.As(err, &verr))
+}
+
+func TestEnv_CheckPrefix(t *testing.T) {
+	e := New(Map{
+		"APP_PORT":          "8080",
+		"APP_TIMEOUTT":      "5s",
+		"APP_ZZZ":           "1",
+		"APP_PASSWORD_FILE": "/run/secrets/password",
+	})
+
+	require.NoError(t, e.CheckPrefix("APP", "PORT", "TIMEOUTT", "ZZZ", "PASSWORD_FILE"))
+
+	err := e.CheckPrefix("APP", "PORT", "TIMEOUT", "PASSWORD")
+	var cerr *CheckError
+	require.True(t, errors.As(err, &cerr))
+	require.Equal(t, []error{
+		&UnknownKeyError{Key: "APP_PASSWORD_FILE"},
+		&UnknownKeyError{Key: "APP_TIMEOUTT", Suggestion: "APP_TIMEOUT"},
+		&UnknownKeyError{Key: "APP_ZZZ"},
+	}, cerr.Errs)
+	require.Equal(t, "env: APP_TIMEOUTT: unknown variable, did you mean APP_TIMEOUT?", cerr.Errs[1].Error())
+
+	e.Files = true
+	err = e.CheckPrefix("APP", "PORT", "TIMEOUT", "PASSWORD")
+	require.True(t, errors.As(err, &cerr))
+	require.Len(t, cerr.Errs, 2)
+}
+
+func TestPrefix_Keys(t *testing.T) {
+	t.Setenv("KEYSTEST_X", "1")
+	t.Setenv("KEYSTEST_TIMEOUTT", "5s")
+
+	p := Prefix("KEYSTEST")
+	require.Equal(t, []string{"TIMEOUTT", "X"}, p.Keys())
+	require.Equal(t, map[string]string{"TIMEOUTT": "5s", "X": "1"}, p.Map())
+	require.NoError(t, p.CheckKeys("X", "TIMEOUTT"))
+
+	r := &Registry{}
+	r.DeclarePrefix(p, Var{Name: "X"}, Var{Name: "TIMEOUT"})
+	err := r.CheckPrefix(p)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "did you mean KEYSTEST_TIMEOUT?")
+}
+
+func TestDistance(t *testing.T) {
+	require.Equal(t, 0, distance("", ""))
+	require.Equal(t, 6, distance("kitten", ""))
+	require.Equal(t, 3, distance("kitten", "sitting"))
+	require.Equal(t, 1, distance("TIMEOUTT", "TIMEOUT"))
+}
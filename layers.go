@@ -0,0 +1,102 @@
+package env
+
+import (
+	"sort"
+)
+
+// Layer is a named Source stacked in Layers.
+type Layer struct {
+	Name   string // the name reported by Layers.Which, e.g. "flags" or ".env.local"
+	Source Source
+}
+
+// Layers is a Source consulting each Layer in order, so earlier layers take precedence over later ones.
+// A variable is retrieved from the first Layer it is present and not empty in, so an empty value, e.g. KEY= in a .env
+// file, does not hide the value of a later Layer. If it is empty in every Layer it is present in, it is retrieved from
+// the first of them.
+//
+// Setting Layers as the Source of Default, rather than replacing Default, keeps its other settings and the Env
+// referenced by values declared against it:
+//
+//	local, _ := env.ReadDotenv(".env.local")
+//	defaults, _ := env.ReadDotenv("defaults.env")
+//	env.Default.Source = env.Layers{
+//		{Name: "flags", Source: overrides},
+//		{Name: "os", Source: env.OS},
+//		{Name: ".env.local", Source: local},
+//		{Name: "defaults.env", Source: defaults},
+//	}
+type Layers []Layer
+
+// Lookup retrieves the value named by key from the first Layer it is present and not empty in, and reports whether it is present.
+func (l Layers) Lookup(key string) (string, bool) {
+	if i := l.find(key); i >= 0 {
+		return l[i].Source.Lookup(key)
+	}
+	return "", false
+}
+
+// Which returns the name of the Layer the value named by key is retrieved from, and reports whether it is present.
+func (l Layers) Which(key string) (string, bool) {
+	if i := l.find(key); i >= 0 {
+		return l[i].Name, true
+	}
+	return "", false
+}
+
+// Locate returns the location reported by the Layer the value named by key is retrieved from, if it implements Locator.
+func (l Layers) Locate(key string) (string, int, bool) {
+	if i := l.find(key); i >= 0 {
+		if loc, ok := l[i].Source.(Locator); ok {
+			return loc.Locate(key)
+		}
+	}
+	return "", 0, false
+}
+
+// Keys returns the keys present in any Layer implementing Enumerator in sorted order.
+func (l Layers) Keys() []string {
+	seen := map[string]bool{}
+	var keys []string
+	for _, layer := range l {
+		lkeys, _ := Keys(layer.Source)
+		for _, k := range lkeys {
+			if !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// find returns the index of the first Layer key is present and not empty in, or else the first it is present in, or -1.
+func (l Layers) find(key string) int {
+	empty := -1
+	for i, layer := range l {
+		v, ok := layer.Source.Lookup(key)
+		if ok && v != "" {
+			return i
+		}
+		if ok && empty < 0 {
+			empty = i
+		}
+	}
+	return empty
+}
+
+// Which returns the name of the Layer the value named by key is retrieved from, and reports whether it is present.
+// It reports false if the Source of e is not Layers.
+func (e *Env) Which(key string) (string, bool) {
+	l, ok := e.source().(Layers)
+	if !ok {
+		return "", false
+	}
+	return l.Which(key)
+}
+
+// Which returns the name of the Layer the value named by key is retrieved from by Default.
+func Which(key string) (string, bool) {
+	return Default.Which(key)
+}
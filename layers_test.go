@@ -0,0 +1,58 @@
+package env
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLayers(t *testing.T) {
+	e := New(Layers{
+		{Name: "flags", Source: Map{"APP_PORT": "9090"}},
+		{Name: "local", Source: Map{"APP_DEBUG": "true", "APP_TIMEOUT": "", "APP_NAME": ""}},
+		{Name: "defaults", Source: Map{"APP_PORT": "8080", "APP_TIMEOUT": "5s", "APP_RETRIES": "3", "APP_NAME": ""}},
+	})
+
+	require.Equal(t, 9090, e.GetInt("APP_PORT"))
+	require.Equal(t, true, e.GetBoolD("APP_DEBUG", false))
+	require.Equal(t, 3, e.GetIntD("APP_RETRIES", 1))
+	require.Equal(t, 5*time.Second, e.GetDurationD("APP_TIMEOUT", time.Second))
+	v, ok := e.Lookup("APP_NAME")
+	require.True(t, ok)
+	require.Equal(t, "", v)
+
+	for key, layer := range map[string]string{
+		"APP_PORT":    "flags",
+		"APP_DEBUG":   "local",
+		"APP_TIMEOUT": "defaults",
+		"APP_RETRIES": "defaults",
+		"APP_NAME":    "local",
+	} {
+		name, ok := e.Which(key)
+		require.True(t, ok)
+		require.Equal(t, layer, name, key)
+	}
+	_, ok = e.Which("MISSING")
+	require.False(t, ok)
+	_, ok = New(Map{}).Which("APP_PORT")
+	require.False(t, ok)
+
+	keys, ok := Keys(e.Source)
+	require.True(t, ok)
+	require.Equal(t, []string{"APP_DEBUG", "APP_NAME", "APP_PORT", "APP_RETRIES", "APP_TIMEOUT"}, keys)
+}
+
+func TestPrefix_Which(t *testing.T) {
+	prev := Default.Source
+	defer func() { Default.Source = prev }()
+	Default.Source = Layers{
+		{Name: "flags", Source: Map{"APP_PORT": "9090"}},
+		{Name: "os", Source: OS},
+	}
+
+	require.Equal(t, 9090, Prefix("APP").GetInt("PORT"))
+	name, ok := Prefix("APP").Which("PORT")
+	require.True(t, ok)
+	require.Equal(t, "flags", name)
+}
@@ -0,0 +1,471 @@
+package env
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultMapSeparator is the MapSeparator used by an Env that does not set one.
+	DefaultMapSeparator = ","
+
+	// DefaultKeyValueSeparator is the KeyValueSeparator used by an Env that does not set one.
+	DefaultKeyValueSeparator = ":"
+)
+
+var (
+	errMissingKeyValueSeparator = errors.New("missing key/value separator")
+	errEmptyMapKey              = errors.New("empty key")
+	errDuplicateMapKey          = errors.New("duplicate key")
+)
+
+// GetStringMap retrieves a map[string]string named by key, splitting the value into entries on the MapSeparator and each entry into
+// a key and value on the KeyValueSeparator.
+// A nil map is returned if the value does not exist or is malformed.
+func (e *Env) GetStringMap(key string) map[string]string {
+	v, _ := e.GetStringMapE(key)
+	return v
+}
+
+// GetStringMapD attempts to retrieve a map[string]string named by key. If the value is not present or is not valid, def is returned instead.
+func (e *Env) GetStringMapD(key string, def map[string]string) map[string]string {
+	defer supplyDefault(e, key, def)
+	v, err := e.GetStringMapE(key)
+	if err != nil {
+		return useDefault(e, key, def)
+	}
+	return v
+}
+
+// GetStringMapE retrieves a map[string]string named by key, splitting the value into entries on the MapSeparator and each entry into
+// a key and value on the KeyValueSeparator.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it is malformed or contains
+// a duplicate key.
+func (e *Env) GetStringMapE(key string) (map[string]string, error) {
+	_, entries, err := e.lookupMapE(key, "map[string]string")
+	if err != nil {
+		return nil, err
+	}
+	v := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		v[entry.key] = entry.value
+	}
+	return v, nil
+}
+
+// GetIntMap retrieves a map[string]int named by key, splitting the value into entries on the MapSeparator and each entry into
+// a key and value on the KeyValueSeparator.
+// A nil map is returned if the value does not exist, is malformed or any value is not a valid int.
+func (e *Env) GetIntMap(key string) map[string]int {
+	v, _ := e.GetIntMapE(key)
+	return v
+}
+
+// GetIntMapD attempts to retrieve a map[string]int named by key. If the value is not present or is not valid, def is returned instead.
+func (e *Env) GetIntMapD(key string, def map[string]int) map[string]int {
+	defer supplyDefault(e, key, def)
+	v, err := e.GetIntMapE(key)
+	if err != nil {
+		return useDefault(e, key, def)
+	}
+	return v
+}
+
+// GetIntMapE retrieves a map[string]int named by key, splitting the value into entries on the MapSeparator and each entry into
+// a key and value on the KeyValueSeparator.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it is malformed, contains
+// a duplicate key or any value is not a valid int.
+func (e *Env) GetIntMapE(key string) (map[string]int, error) {
+	raw, entries, err := e.lookupMapE(key, "map[string]int")
+	if err != nil {
+		return nil, err
+	}
+	v := make(map[string]int, len(entries))
+	for i, entry := range entries {
+		if v[entry.key], err = parseInt(entry.value); err != nil {
+			return nil, e.elementError(key, raw, "map[string]int", i, entry.raw, err)
+		}
+	}
+	return v, nil
+}
+
+// GetInt64Map retrieves a map[string]int64 named by key, splitting the value into entries on the MapSeparator and each entry into
+// a key and value on the KeyValueSeparator.
+// A nil map is returned if the value does not exist, is malformed or any value is not a valid int64.
+func (e *Env) GetInt64Map(key string) map[string]int64 {
+	v, _ := e.GetInt64MapE(key)
+	return v
+}
+
+// GetInt64MapD attempts to retrieve a map[string]int64 named by key. If the value is not present or is not valid, def is returned instead.
+func (e *Env) GetInt64MapD(key string, def map[string]int64) map[string]int64 {
+	defer supplyDefault(e, key, def)
+	v, err := e.GetInt64MapE(key)
+	if err != nil {
+		return useDefault(e, key, def)
+	}
+	return v
+}
+
+// GetInt64MapE retrieves a map[string]int64 named by key, splitting the value into entries on the MapSeparator and each entry into
+// a key and value on the KeyValueSeparator.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it is malformed, contains
+// a duplicate key or any value is not a valid int64.
+func (e *Env) GetInt64MapE(key string) (map[string]int64, error) {
+	raw, entries, err := e.lookupMapE(key, "map[string]int64")
+	if err != nil {
+		return nil, err
+	}
+	v := make(map[string]int64, len(entries))
+	for i, entry := range entries {
+		if v[entry.key], err = parseInt64(entry.value); err != nil {
+			return nil, e.elementError(key, raw, "map[string]int64", i, entry.raw, err)
+		}
+	}
+	return v, nil
+}
+
+// GetUInt64Map retrieves a map[string]uint64 named by key, splitting the value into entries on the MapSeparator and each entry into
+// a key and value on the KeyValueSeparator.
+// A nil map is returned if the value does not exist, is malformed or any value is not a valid uint64.
+func (e *Env) GetUInt64Map(key string) map[string]uint64 {
+	v, _ := e.GetUInt64MapE(key)
+	return v
+}
+
+// GetUInt64MapD attempts to retrieve a map[string]uint64 named by key. If the value is not present or is not valid, def is returned instead.
+func (e *Env) GetUInt64MapD(key string, def map[string]uint64) map[string]uint64 {
+	defer supplyDefault(e, key, def)
+	v, err := e.GetUInt64MapE(key)
+	if err != nil {
+		return useDefault(e, key, def)
+	}
+	return v
+}
+
+// GetUInt64MapE retrieves a map[string]uint64 named by key, splitting the value into entries on the MapSeparator and each entry into
+// a key and value on the KeyValueSeparator.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it is malformed, contains
+// a duplicate key or any value is not a valid uint64.
+func (e *Env) GetUInt64MapE(key string) (map[string]uint64, error) {
+	raw, entries, err := e.lookupMapE(key, "map[string]uint64")
+	if err != nil {
+		return nil, err
+	}
+	v := make(map[string]uint64, len(entries))
+	for i, entry := range entries {
+		if v[entry.key], err = parseUInt64(entry.value); err != nil {
+			return nil, e.elementError(key, raw, "map[string]uint64", i, entry.raw, err)
+		}
+	}
+	return v, nil
+}
+
+// GetFloat64Map retrieves a map[string]float64 named by key, splitting the value into entries on the MapSeparator and each entry into
+// a key and value on the KeyValueSeparator.
+// A nil map is returned if the value does not exist, is malformed or any value is not a valid float64.
+func (e *Env) GetFloat64Map(key string) map[string]float64 {
+	v, _ := e.GetFloat64MapE(key)
+	return v
+}
+
+// GetFloat64MapD attempts to retrieve a map[string]float64 named by key. If the value is not present or is not valid, def is returned instead.
+func (e *Env) GetFloat64MapD(key string, def map[string]float64) map[string]float64 {
+	defer supplyDefault(e, key, def)
+	v, err := e.GetFloat64MapE(key)
+	if err != nil {
+		return useDefault(e, key, def)
+	}
+	return v
+}
+
+// GetFloat64MapE retrieves a map[string]float64 named by key, splitting the value into entries on the MapSeparator and each entry into
+// a key and value on the KeyValueSeparator.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it is malformed, contains
+// a duplicate key or any value is not a valid float64.
+func (e *Env) GetFloat64MapE(key string) (map[string]float64, error) {
+	raw, entries, err := e.lookupMapE(key, "map[string]float64")
+	if err != nil {
+		return nil, err
+	}
+	v := make(map[string]float64, len(entries))
+	for i, entry := range entries {
+		if v[entry.key], err = parseFloat64(entry.value); err != nil {
+			return nil, e.elementError(key, raw, "map[string]float64", i, entry.raw, err)
+		}
+	}
+	return v, nil
+}
+
+// GetBoolMap retrieves a map[string]bool named by key, splitting the value into entries on the MapSeparator and each entry into
+// a key and value on the KeyValueSeparator.
+// A nil map is returned if the value does not exist, is malformed or any value is not a valid bool.
+func (e *Env) GetBoolMap(key string) map[string]bool {
+	v, _ := e.GetBoolMapE(key)
+	return v
+}
+
+// GetBoolMapD attempts to retrieve a map[string]bool named by key. If the value is not present or is not valid, def is returned instead.
+func (e *Env) GetBoolMapD(key string, def map[string]bool) map[string]bool {
+	defer supplyDefault(e, key, def)
+	v, err := e.GetBoolMapE(key)
+	if err != nil {
+		return useDefault(e, key, def)
+	}
+	return v
+}
+
+// GetBoolMapE retrieves a map[string]bool named by key, splitting the value into entries on the MapSeparator and each entry into
+// a key and value on the KeyValueSeparator.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it is malformed, contains
+// a duplicate key or any value is not a valid bool.
+func (e *Env) GetBoolMapE(key string) (map[string]bool, error) {
+	raw, entries, err := e.lookupMapE(key, "map[string]bool")
+	if err != nil {
+		return nil, err
+	}
+	v := make(map[string]bool, len(entries))
+	for i, entry := range entries {
+		if v[entry.key], err = parseBool(entry.value); err != nil {
+			return nil, e.elementError(key, raw, "map[string]bool", i, entry.raw, err)
+		}
+	}
+	return v, nil
+}
+
+// GetDurationMap retrieves a map[string]time.Duration named by key, splitting the value into entries on the MapSeparator and each entry into
+// a key and value on the KeyValueSeparator.
+// A nil map is returned if the value does not exist, is malformed or any value is not a valid time.Duration.
+func (e *Env) GetDurationMap(key string) map[string]time.Duration {
+	v, _ := e.GetDurationMapE(key)
+	return v
+}
+
+// GetDurationMapD attempts to retrieve a map[string]time.Duration named by key. If the value is not present or is not valid, def is returned instead.
+func (e *Env) GetDurationMapD(key string, def map[string]time.Duration) map[string]time.Duration {
+	defer supplyDefault(e, key, def)
+	v, err := e.GetDurationMapE(key)
+	if err != nil {
+		return useDefault(e, key, def)
+	}
+	return v
+}
+
+// GetDurationMapE retrieves a map[string]time.Duration named by key, splitting the value into entries on the MapSeparator and each entry into
+// a key and value on the KeyValueSeparator.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it is malformed, contains
+// a duplicate key or any value is not a valid time.Duration.
+func (e *Env) GetDurationMapE(key string) (map[string]time.Duration, error) {
+	raw, entries, err := e.lookupMapE(key, "map[string]time.Duration")
+	if err != nil {
+		return nil, err
+	}
+	v := make(map[string]time.Duration, len(entries))
+	for i, entry := range entries {
+		if v[entry.key], err = parseDuration(entry.value); err != nil {
+			return nil, e.elementError(key, raw, "map[string]time.Duration", i, entry.raw, err)
+		}
+	}
+	return v, nil
+}
+
+func (e *Env) mapSeparator() string {
+	if e.MapSeparator == "" {
+		return DefaultMapSeparator
+	}
+	return e.MapSeparator
+}
+
+func (e *Env) keyValueSeparator() string {
+	if e.KeyValueSeparator == "" {
+		return DefaultKeyValueSeparator
+	}
+	return e.KeyValueSeparator
+}
+
+type mapEntry struct {
+	raw   string
+	key   string
+	value string
+}
+
+func (e *Env) lookupMapE(key, typ string) (string, []mapEntry, error) {
+	raw, err := e.lookupE(key, typ)
+	if err != nil {
+		return "", nil, err
+	}
+	entries, err := splitMap(raw, e.mapSeparator(), e.keyValueSeparator())
+	if err != nil {
+		return "", nil, e.parseError(key, raw, typ, err)
+	}
+	return raw, entries, nil
+}
+
+// GetStringMap retrieves a map[string]string named by key, splitting the value into entries on the MapSeparator and each entry into
+// a key and value on the KeyValueSeparator.
+// A nil map is returned if the value does not exist or is malformed.
+func GetStringMap(key string) map[string]string {
+	return Default.GetStringMap(key)
+}
+
+// GetStringMapD attempts to retrieve a map[string]string named by key. If the value is not present or is not valid, def is returned instead.
+func GetStringMapD(key string, def map[string]string) map[string]string {
+	return Default.GetStringMapD(key, def)
+}
+
+// GetStringMapE retrieves a map[string]string named by key, splitting the value into entries on the MapSeparator and each entry into
+// a key and value on the KeyValueSeparator.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it is malformed or contains
+// a duplicate key.
+func GetStringMapE(key string) (map[string]string, error) {
+	return Default.GetStringMapE(key)
+}
+
+// GetIntMap retrieves a map[string]int named by key, splitting the value into entries on the MapSeparator and each entry into
+// a key and value on the KeyValueSeparator.
+// A nil map is returned if the value does not exist, is malformed or any value is not a valid int.
+func GetIntMap(key string) map[string]int {
+	return Default.GetIntMap(key)
+}
+
+// GetIntMapD attempts to retrieve a map[string]int named by key. If the value is not present or is not valid, def is returned instead.
+func GetIntMapD(key string, def map[string]int) map[string]int {
+	return Default.GetIntMapD(key, def)
+}
+
+// GetIntMapE retrieves a map[string]int named by key, splitting the value into entries on the MapSeparator and each entry into
+// a key and value on the KeyValueSeparator.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it is malformed, contains
+// a duplicate key or any value is not a valid int.
+func GetIntMapE(key string) (map[string]int, error) {
+	return Default.GetIntMapE(key)
+}
+
+// GetInt64Map retrieves a map[string]int64 named by key, splitting the value into entries on the MapSeparator and each entry into
+// a key and value on the KeyValueSeparator.
+// A nil map is returned if the value does not exist, is malformed or any value is not a valid int64.
+func GetInt64Map(key string) map[string]int64 {
+	return Default.GetInt64Map(key)
+}
+
+// GetInt64MapD attempts to retrieve a map[string]int64 named by key. If the value is not present or is not valid, def is returned instead.
+func GetInt64MapD(key string, def map[string]int64) map[string]int64 {
+	return Default.GetInt64MapD(key, def)
+}
+
+// GetInt64MapE retrieves a map[string]int64 named by key, splitting the value into entries on the MapSeparator and each entry into
+// a key and value on the KeyValueSeparator.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it is malformed, contains
+// a duplicate key or any value is not a valid int64.
+func GetInt64MapE(key string) (map[string]int64, error) {
+	return Default.GetInt64MapE(key)
+}
+
+// GetUInt64Map retrieves a map[string]uint64 named by key, splitting the value into entries on the MapSeparator and each entry into
+// a key and value on the KeyValueSeparator.
+// A nil map is returned if the value does not exist, is malformed or any value is not a valid uint64.
+func GetUInt64Map(key string) map[string]uint64 {
+	return Default.GetUInt64Map(key)
+}
+
+// GetUInt64MapD attempts to retrieve a map[string]uint64 named by key. If the value is not present or is not valid, def is returned instead.
+func GetUInt64MapD(key string, def map[string]uint64) map[string]uint64 {
+	return Default.GetUInt64MapD(key, def)
+}
+
+// GetUInt64MapE retrieves a map[string]uint64 named by key, splitting the value into entries on the MapSeparator and each entry into
+// a key and value on the KeyValueSeparator.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it is malformed, contains
+// a duplicate key or any value is not a valid uint64.
+func GetUInt64MapE(key string) (map[string]uint64, error) {
+	return Default.GetUInt64MapE(key)
+}
+
+// GetFloat64Map retrieves a map[string]float64 named by key, splitting the value into entries on the MapSeparator and each entry into
+// a key and value on the KeyValueSeparator.
+// A nil map is returned if the value does not exist, is malformed or any value is not a valid float64.
+func GetFloat64Map(key string) map[string]float64 {
+	return Default.GetFloat64Map(key)
+}
+
+// GetFloat64MapD attempts to retrieve a map[string]float64 named by key. If the value is not present or is not valid, def is returned instead.
+func GetFloat64MapD(key string, def map[string]float64) map[string]float64 {
+	return Default.GetFloat64MapD(key, def)
+}
+
+// GetFloat64MapE retrieves a map[string]float64 named by key, splitting the value into entries on the MapSeparator and each entry into
+// a key and value on the KeyValueSeparator.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it is malformed, contains
+// a duplicate key or any value is not a valid float64.
+func GetFloat64MapE(key string) (map[string]float64, error) {
+	return Default.GetFloat64MapE(key)
+}
+
+// GetBoolMap retrieves a map[string]bool named by key, splitting the value into entries on the MapSeparator and each entry into
+// a key and value on the KeyValueSeparator.
+// A nil map is returned if the value does not exist, is malformed or any value is not a valid bool.
+func GetBoolMap(key string) map[string]bool {
+	return Default.GetBoolMap(key)
+}
+
+// GetBoolMapD attempts to retrieve a map[string]bool named by key. If the value is not present or is not valid, def is returned instead.
+func GetBoolMapD(key string, def map[string]bool) map[string]bool {
+	return Default.GetBoolMapD(key, def)
+}
+
+// GetBoolMapE retrieves a map[string]bool named by key, splitting the value into entries on the MapSeparator and each entry into
+// a key and value on the KeyValueSeparator.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it is malformed, contains
+// a duplicate key or any value is not a valid bool.
+func GetBoolMapE(key string) (map[string]bool, error) {
+	return Default.GetBoolMapE(key)
+}
+
+// GetDurationMap retrieves a map[string]time.Duration named by key, splitting the value into entries on the MapSeparator and each entry into
+// a key and value on the KeyValueSeparator.
+// A nil map is returned if the value does not exist, is malformed or any value is not a valid time.Duration.
+func GetDurationMap(key string) map[string]time.Duration {
+	return Default.GetDurationMap(key)
+}
+
+// GetDurationMapD attempts to retrieve a map[string]time.Duration named by key. If the value is not present or is not valid, def is returned instead.
+func GetDurationMapD(key string, def map[string]time.Duration) map[string]time.Duration {
+	return Default.GetDurationMapD(key, def)
+}
+
+// GetDurationMapE retrieves a map[string]time.Duration named by key, splitting the value into entries on the MapSeparator and each entry into
+// a key and value on the KeyValueSeparator.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it is malformed, contains
+// a duplicate key or any value is not a valid time.Duration.
+func GetDurationMapE(key string) (map[string]time.Duration, error) {
+	return Default.GetDurationMapE(key)
+}
+
+// splitMap splits s into entries on sep, and each entry into a key and value on kvSep.
+// Errors with individual entries are returned as an *ElementError.
+func splitMap(s, sep, kvSep string) ([]mapEntry, error) {
+	elems, err := splitList(s, sep)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]mapEntry, len(elems))
+	seen := make(map[string]bool, len(elems))
+	for i, elem := range elems {
+		j := strings.Index(elem, kvSep)
+		if j < 0 {
+			return nil, &ElementError{Index: i, Value: elem, Err: errMissingKeyValueSeparator}
+		}
+		k := strings.TrimSpace(elem[:j])
+		switch {
+		case k == "":
+			return nil, &ElementError{Index: i, Value: elem, Err: errEmptyMapKey}
+		case seen[k]:
+			return nil, &ElementError{Index: i, Value: elem, Err: errDuplicateMapKey}
+		}
+		seen[k] = true
+		entries[i] = mapEntry{raw: elem, key: k, value: strings.TrimSpace(elem[j+len(kvSep):])}
+	}
+	return entries, nil
+}
@@ -0,0 +1,88 @@
+package env
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetMap(t *testing.T) {
+	e := New(Map{
+		"LABELS":    "team:core, tier : 1, 'url:http://a.com,b'",
+		"COUNTS":    "a:1,b:-2",
+		"INT64S":    "a:-64",
+		"UINT64S":   "a:64",
+		"FLAGS":     "a:yes,b:no",
+		"TIMEOUTS":  "read:1s,write:1m",
+		"FLOATS":    "a:0.5",
+		"EMPTY_VAL": "a:",
+	})
+
+	require.Equal(t, map[string]string{"team": "core", "tier": "1", "url": "http://a.com,b"}, e.GetStringMap("LABELS"))
+	require.Equal(t, map[string]string{"a": ""}, e.GetStringMap("EMPTY_VAL"))
+	require.Equal(t, map[string]int{"a": 1, "b": -2}, e.GetIntMap("COUNTS"))
+	require.Equal(t, map[string]int64{"a": -64}, e.GetInt64Map("INT64S"))
+	require.Equal(t, map[string]uint64{"a": 64}, e.GetUInt64Map("UINT64S"))
+	require.Equal(t, map[string]bool{"a": true, "b": false}, e.GetBoolMap("FLAGS"))
+	require.Equal(t, map[string]time.Duration{"read": time.Second, "write": time.Minute}, e.GetDurationMap("TIMEOUTS"))
+	require.Equal(t, map[string]float64{"a": 0.5}, e.GetFloat64Map("FLOATS"))
+	require.Equal(t, map[string]int{"x": 1}, e.GetIntMapD("MISSING", map[string]int{"x": 1}))
+	require.Nil(t, e.GetIntMap("LABELS"))
+}
+
+func TestGetMap_Separators(t *testing.T) {
+	e := New(Map{"WEIGHTS": "a=0.5;b=0.25"})
+	e.MapSeparator = ";"
+	e.KeyValueSeparator = "="
+
+	require.Equal(t, map[string]float64{"a": 0.5, "b": 0.25}, e.GetFloat64Map("WEIGHTS"))
+}
+
+func TestGetMapE(t *testing.T) {
+	e := New(Map{
+		"DUPLICATE": "a:1,b:2,a:3",
+		"MISSING":   "a:1,b",
+		"EMPTY":     ":1",
+		"BAD":       "a:1,b:x",
+	})
+
+	tests := []struct {
+		key     string
+		index   int
+		elemErr error
+	}{
+		{key: "DUPLICATE", index: 2, elemErr: errDuplicateMapKey},
+		{key: "MISSING", index: 1, elemErr: errMissingKeyValueSeparator},
+		{key: "EMPTY", index: 0, elemErr: errEmptyMapKey},
+		{key: "BAD", index: 1, elemErr: strconv.ErrSyntax},
+	}
+
+	for _, test := range tests {
+		t.Run(test.key, func(t *testing.T) {
+			_, err := e.GetIntMapE(test.key)
+
+			var parseErr *ParseError
+			require.True(t, errors.As(err, &parseErr))
+			require.Equal(t, "map[string]int", parseErr.Type)
+
+			var elemErr *ElementError
+			require.True(t, errors.As(err, &elemErr))
+			require.Equal(t, test.index, elemErr.Index)
+			require.True(t, errors.Is(err, test.elemErr))
+		})
+	}
+
+	_, err := e.GetStringMapE("NOT_SET")
+	require.True(t, errors.Is(err, ErrNotSet))
+}
+
+func TestPrefix_GetMap(t *testing.T) {
+	t.Setenv("FOO_LABELS", "team:core")
+
+	prefix := Prefix("FOO")
+	require.Equal(t, map[string]string{"team": "core"}, prefix.GetStringMap("LABELS"))
+	require.Equal(t, map[string]int{"a": 1}, prefix.GetIntMapD("LABELS", map[string]int{"a": 1}))
+}
@@ -0,0 +1,205 @@
+package env
+
+import (
+	"encoding"
+	"errors"
+	"flag"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	durationType        = typeOf[time.Duration]()
+	textMarshalerType   = typeOf[encoding.TextMarshaler]()
+	errNotRepresentable = errors.New("cannot be represented")
+)
+
+// Marshal returns the values of the tagged fields of the struct pointed to by v as KEY=value pairs sorted by key,
+// suitable for the Env of an exec.Cmd. See Env.MarshalMap for details.
+func Marshal(v interface{}) ([]string, error) {
+	return Default.Marshal(v)
+}
+
+// MarshalMap returns the values of the tagged fields of the struct pointed to by v, keyed by the names of the
+// variables. See Env.MarshalMap for details.
+func MarshalMap(v interface{}) (Map, error) {
+	return Default.MarshalMap(v)
+}
+
+// MarshalMasked is like MarshalMap, but replaces the values of secret fields with SecretMask.
+func MarshalMasked(v interface{}) (Map, error) {
+	return Default.MarshalMasked(v)
+}
+
+// Marshal returns the values of the tagged fields of the struct pointed to by v as KEY=value pairs sorted by key.
+// See MarshalMap for details.
+func (e *Env) Marshal(v interface{}) ([]string, error) {
+	m, err := e.MarshalMap(v)
+	if err != nil {
+		return nil, err
+	}
+	return m.Environ(), nil
+}
+
+// MarshalMap returns the values of the tagged fields of the struct pointed to by v, keyed by the names of the
+// variables, so that Unmarshal with e restores them. Fields are resolved as by Unmarshal.
+//
+// Values are formatted as the getters parse them: time.Duration with its String method, bools as true or false, types
+// implementing encoding.TextMarshaler or flag.Value with their methods, and slices and maps joined with the separators
+// of e, quoting elements where necessary. If e expands values, $ is escaped as $$. Secret fields are included as is;
+// use MarshalMasked to mask them.
+func (e *Env) MarshalMap(v interface{}) (Map, error) {
+	return e.marshal(v, "", false)
+}
+
+// MarshalMasked is like MarshalMap, but replaces the values of secret fields with SecretMask, e.g. for generating
+// documentation or logging a configuration.
+func (e *Env) MarshalMasked(v interface{}) (Map, error) {
+	return e.marshal(v, "", true)
+}
+
+func (e *Env) marshal(v interface{}, p Prefix, mask bool) (Map, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, errors.New("env: Marshal requires a struct or a non-nil pointer to a struct")
+	}
+
+	m := Map{}
+	err := walkStruct(rv, p, func(f structField) error {
+		if !f.value.CanInterface() {
+			return nil
+		}
+		if f.secret && mask {
+			m[f.key] = SecretMask
+			return nil
+		}
+		s, err := e.format(f.value)
+		if err == errUnsupportedType {
+			return fmt.Errorf("env: field %s (%s): unsupported type %s", f.field.Name, f.key, f.value.Type())
+		} else if err != nil {
+			return fmt.Errorf("env: field %s (%s): %w", f.field.Name, f.key, err)
+		}
+		m[f.key] = e.escape(s)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// format formats v as a value that e parses back into v.
+func (e *Env) format(v reflect.Value) (string, error) {
+	t := v.Type()
+	switch {
+	case t == secretType:
+		return v.String(), nil
+	case t == durationType:
+		return time.Duration(v.Int()).String(), nil
+	case t.Implements(textMarshalerType):
+		b, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+		return string(b), err
+	case reflect.PointerTo(t).Implements(textMarshalerType), reflect.PointerTo(t).Implements(flagValueType):
+		pv := reflect.New(t)
+		pv.Elem().Set(v)
+		if m, ok := pv.Interface().(encoding.TextMarshaler); ok {
+			b, err := m.MarshalText()
+			return string(b), err
+		}
+		return pv.Interface().(flag.Value).String(), nil
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, t.Bits()), nil
+	case reflect.Slice:
+		return e.formatSlice(v)
+	case reflect.Map:
+		return e.formatMap(v)
+	}
+	return "", errUnsupportedType
+}
+
+// escape escapes s, if e expands values, so that it is retrieved unchanged.
+func (e *Env) escape(s string) string {
+	if !e.Expand {
+		return s
+	}
+	return strings.ReplaceAll(s, "$", "$$")
+}
+
+func (e *Env) formatSlice(v reflect.Value) (string, error) {
+	if !scalar(v.Type().Elem()) {
+		return "", errUnsupportedType
+	}
+
+	sep := e.listSeparator()
+	elems := make([]string, v.Len())
+	for i := range elems {
+		s, err := e.format(v.Index(i))
+		if err != nil {
+			return "", err
+		}
+		if elems[i], err = quoteElement(s, sep); err != nil {
+			return "", &ElementError{Index: i, Value: s, Err: err}
+		}
+	}
+	return strings.Join(elems, sep), nil
+}
+
+func (e *Env) formatMap(v reflect.Value) (string, error) {
+	t := v.Type()
+	if t.Key().Kind() != reflect.String || !scalar(t.Elem()) {
+		return "", errUnsupportedType
+	}
+
+	keys := v.MapKeys()
+	sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+
+	sep, kvSep := e.mapSeparator(), e.keyValueSeparator()
+	entries := make([]string, len(keys))
+	for i, k := range keys {
+		s, err := e.format(v.MapIndex(k))
+		if err != nil {
+			return "", err
+		}
+		key := k.String()
+		entry := key + kvSep + s
+		if strings.TrimSpace(key) != key || key == "" || strings.Contains(key, kvSep) || strings.TrimSpace(s) != s {
+			return "", &ElementError{Index: i, Value: entry, Err: errNotRepresentable}
+		}
+		if entries[i], err = quoteElement(entry, sep); err != nil {
+			return "", &ElementError{Index: i, Value: entry, Err: err}
+		}
+	}
+	return strings.Join(entries, sep), nil
+}
+
+// quoteElement quotes s, if necessary, so it is split by splitList as a single element.
+func quoteElement(s, sep string) (string, error) {
+	trimmed := strings.TrimSpace(s)
+	if trimmed == s && !strings.Contains(s, sep) && !strings.HasPrefix(s, `"`) && !strings.HasPrefix(s, "'") {
+		return s, nil
+	}
+	for _, q := range []string{`"`, "'"} {
+		if !strings.Contains(s, q) {
+			return q + s + q, nil
+		}
+	}
+	return "", errNotRepresentable
+}
@@ -0,0 +1,138 @@
+package env
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type marshalConfig struct {
+	Name     string            `env:"NAME"`
+	Port     uint16            `env:"PORT"`
+	Ratio    float32           `env:"RATIO"`
+	Debug    bool              `env:"DEBUG"`
+	Timeout  time.Duration     `env:"TIMEOUT"`
+	Hosts    []string          `env:"HOSTS"`
+	Delays   []time.Duration   `env:"DELAYS"`
+	Limits   map[string]int    `env:"LIMITS"`
+	Labels   map[string]string `env:"LABELS"`
+	IP       net.IP            `env:"IP"`
+	Password Secret            `env:"PASSWORD"`
+	Token    string            `env:"TOKEN,secret"`
+	Ignored  string            `env:"-"`
+
+	DB unmarshalDB `env:"DB"`
+	unmarshalEmbedded
+}
+
+func TestMarshal(t *testing.T) {
+	cfg := marshalConfig{
+		Name:     "app",
+		Port:     8080,
+		Ratio:    0.1,
+		Debug:    true,
+		Timeout:  90 * time.Second,
+		Hosts:    []string{"a", "b,c", " d ", `"e"`},
+		Delays:   []time.Duration{time.Second, time.Minute},
+		Limits:   map[string]int{"b": 2, "a": 1},
+		Labels:   map[string]string{"team": "a,b"},
+		IP:       net.ParseIP("10.0.0.1"),
+		Password: "hunter2",
+		Token:    "t0k3n",
+		Ignored:  "ignored",
+		DB:       unmarshalDB{Host: "db", Port: 5432},
+	}
+	cfg.Embedded = "embedded"
+
+	m, err := MarshalMap(&cfg)
+	require.NoError(t, err)
+	require.Equal(t, Map{
+		"NAME":     "app",
+		"PORT":     "8080",
+		"RATIO":    "0.1",
+		"DEBUG":    "true",
+		"TIMEOUT":  "1m30s",
+		"HOSTS":    `a,"b,c"," d ",'"e"'`,
+		"DELAYS":   "1s,1m0s",
+		"LIMITS":   "a:1,b:2",
+		"LABELS":   `"team:a,b"`,
+		"IP":       "10.0.0.1",
+		"PASSWORD": "hunter2",
+		"TOKEN":    "t0k3n",
+		"DB_HOST":  "db",
+		"DB_PORT":  "5432",
+		"EMBEDDED": "embedded",
+	}, m)
+
+	var got marshalConfig
+	require.NoError(t, New(m).Unmarshal(&got))
+	cfg.Ignored = ""
+	require.Equal(t, cfg, got)
+
+	environ, err := Marshal(cfg)
+	require.NoError(t, err)
+	require.Equal(t, "DB_HOST=db", environ[0])
+	require.Len(t, environ, len(m))
+}
+
+func TestMarshal_Prefix(t *testing.T) {
+	m, err := Prefix("APP").MarshalMasked(&marshalConfig{Token: "t0k3n", Password: "hunter2", DB: unmarshalDB{Port: 1}})
+	require.NoError(t, err)
+	require.Equal(t, SecretMask, m["APP_TOKEN"])
+	require.Equal(t, SecretMask, m["APP_PASSWORD"])
+	require.Equal(t, "1", m["APP_DB_PORT"])
+
+	environ, err := Prefix("APP").Marshal(unmarshalDB{Host: "db"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"APP_HOST=db", "APP_PORT=0"}, environ)
+}
+
+func TestEnv_Marshal_Separators(t *testing.T) {
+	e := &Env{ListSeparator: ";", MapSeparator: ";", KeyValueSeparator: "="}
+	cfg := struct {
+		Hosts  []string       `env:"HOSTS"`
+		Limits map[string]int `env:"LIMITS"`
+	}{[]string{"a,b", "c"}, map[string]int{"x": 1, "y": 2}}
+
+	m, err := e.MarshalMap(cfg)
+	require.NoError(t, err)
+	require.Equal(t, Map{"HOSTS": "a,b;c", "LIMITS": "x=1;y=2"}, m)
+}
+
+func TestEnv_Marshal_Expand(t *testing.T) {
+	e := &Env{Expand: true}
+	cfg := struct {
+		DSN   string   `env:"DSN"`
+		Hosts []string `env:"HOSTS"`
+	}{"user:pa$$word@$HOST", []string{"a$b", "${c}"}}
+
+	m, err := e.MarshalMap(cfg)
+	require.NoError(t, err)
+	require.Equal(t, "user:pa$$$$word@$$HOST", m["DSN"])
+
+	var got struct {
+		DSN   string   `env:"DSN"`
+		Hosts []string `env:"HOSTS"`
+	}
+	e.Source = m
+	require.NoError(t, e.Unmarshal(&got))
+	require.Equal(t, cfg.DSN, got.DSN)
+	require.Equal(t, cfg.Hosts, got.Hosts)
+}
+
+func TestMarshal_Errors(t *testing.T) {
+	_, err := Marshal(nil)
+	require.Error(t, err)
+
+	_, err = Marshal(struct {
+		Hosts []string `env:"HOSTS"`
+	}{[]string{`'"'`}})
+	require.EqualError(t, err, `env: field Hosts (HOSTS): element 0 "'\"'": cannot be represented`)
+
+	_, err = Marshal(struct {
+		C chan int `env:"C"`
+	}{})
+	require.EqualError(t, err, "env: field C (C): unsupported type chan int")
+}
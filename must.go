@@ -0,0 +1,259 @@
+package env
+
+import "time"
+
+// MustGetString retrieves a string named by key.
+// It panics with the error of GetStringE if the value is not present.
+func (e *Env) MustGetString(key string) string {
+	v, err := e.GetStringE(key)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// MustGetInt retrieves an int named by key.
+// It panics with the error of GetIntE if the value is not present or is not a valid int.
+func (e *Env) MustGetInt(key string) int {
+	v, err := e.GetIntE(key)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// MustGetInt8 retrieves an int8 named by key.
+// It panics with the error of GetInt8E if the value is not present or is not a valid int8.
+func (e *Env) MustGetInt8(key string) int8 {
+	v, err := e.GetInt8E(key)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// MustGetInt16 retrieves an int16 named by key.
+// It panics with the error of GetInt16E if the value is not present or is not a valid int16.
+func (e *Env) MustGetInt16(key string) int16 {
+	v, err := e.GetInt16E(key)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// MustGetInt32 retrieves an int32 named by key.
+// It panics with the error of GetInt32E if the value is not present or is not a valid int32.
+func (e *Env) MustGetInt32(key string) int32 {
+	v, err := e.GetInt32E(key)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// MustGetInt64 retrieves an int64 named by key.
+// It panics with the error of GetInt64E if the value is not present or is not a valid int64.
+func (e *Env) MustGetInt64(key string) int64 {
+	v, err := e.GetInt64E(key)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// MustGetUInt retrieves an uint named by key.
+// It panics with the error of GetUIntE if the value is not present or is not a valid uint.
+func (e *Env) MustGetUInt(key string) uint {
+	v, err := e.GetUIntE(key)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// MustGetUInt8 retrieves an uint8 named by key.
+// It panics with the error of GetUInt8E if the value is not present or is not a valid uint8.
+func (e *Env) MustGetUInt8(key string) uint8 {
+	v, err := e.GetUInt8E(key)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// MustGetUInt16 retrieves an uint16 named by key.
+// It panics with the error of GetUInt16E if the value is not present or is not a valid uint16.
+func (e *Env) MustGetUInt16(key string) uint16 {
+	v, err := e.GetUInt16E(key)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// MustGetUInt32 retrieves an uint32 named by key.
+// It panics with the error of GetUInt32E if the value is not present or is not a valid uint32.
+func (e *Env) MustGetUInt32(key string) uint32 {
+	v, err := e.GetUInt32E(key)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// MustGetUInt64 retrieves an uint64 named by key.
+// It panics with the error of GetUInt64E if the value is not present or is not a valid uint64.
+func (e *Env) MustGetUInt64(key string) uint64 {
+	v, err := e.GetUInt64E(key)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// MustGetFloat32 retrieves a float32 named by key.
+// It panics with the error of GetFloat32E if the value is not present or is not a valid float32.
+func (e *Env) MustGetFloat32(key string) float32 {
+	v, err := e.GetFloat32E(key)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// MustGetFloat64 retrieves a float64 named by key.
+// It panics with the error of GetFloat64E if the value is not present or is not a valid float64.
+func (e *Env) MustGetFloat64(key string) float64 {
+	v, err := e.GetFloat64E(key)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// MustGetBool retrieves a bool named by key.
+// It panics with the error of GetBoolE if the value is not present or is not a valid bool.
+func (e *Env) MustGetBool(key string) bool {
+	v, err := e.GetBoolE(key)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// MustGetDuration retrieves a time.Duration named by key.
+// It panics with the error of GetDurationE if the value is not present or is not a valid time.Duration.
+func (e *Env) MustGetDuration(key string) time.Duration {
+	v, err := e.GetDurationE(key)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// MustGetString retrieves a string named by key.
+// It panics with the error of GetStringE if the value is not present.
+func MustGetString(key string) string {
+	return Default.MustGetString(key)
+}
+
+// MustGetInt retrieves an int named by key.
+// It panics with the error of GetIntE if the value is not present or is not a valid int.
+func MustGetInt(key string) int {
+	return Default.MustGetInt(key)
+}
+
+// MustGetInt8 retrieves an int8 named by key.
+// It panics with the error of GetInt8E if the value is not present or is not a valid int8.
+func MustGetInt8(key string) int8 {
+	return Default.MustGetInt8(key)
+}
+
+// MustGetInt16 retrieves an int16 named by key.
+// It panics with the error of GetInt16E if the value is not present or is not a valid int16.
+func MustGetInt16(key string) int16 {
+	return Default.MustGetInt16(key)
+}
+
+// MustGetInt32 retrieves an int32 named by key.
+// It panics with the error of GetInt32E if the value is not present or is not a valid int32.
+func MustGetInt32(key string) int32 {
+	return Default.MustGetInt32(key)
+}
+
+// MustGetInt64 retrieves an int64 named by key.
+// It panics with the error of GetInt64E if the value is not present or is not a valid int64.
+func MustGetInt64(key string) int64 {
+	return Default.MustGetInt64(key)
+}
+
+// MustGetUInt retrieves an uint named by key.
+// It panics with the error of GetUIntE if the value is not present or is not a valid uint.
+func MustGetUInt(key string) uint {
+	return Default.MustGetUInt(key)
+}
+
+// MustGetUInt8 retrieves an uint8 named by key.
+// It panics with the error of GetUInt8E if the value is not present or is not a valid uint8.
+func MustGetUInt8(key string) uint8 {
+	return Default.MustGetUInt8(key)
+}
+
+// MustGetUInt16 retrieves an uint16 named by key.
+// It panics with the error of GetUInt16E if the value is not present or is not a valid uint16.
+func MustGetUInt16(key string) uint16 {
+	return Default.MustGetUInt16(key)
+}
+
+// MustGetUInt32 retrieves an uint32 named by key.
+// It panics with the error of GetUInt32E if the value is not present or is not a valid uint32.
+func MustGetUInt32(key string) uint32 {
+	return Default.MustGetUInt32(key)
+}
+
+// MustGetUInt64 retrieves an uint64 named by key.
+// It panics with the error of GetUInt64E if the value is not present or is not a valid uint64.
+func MustGetUInt64(key string) uint64 {
+	return Default.MustGetUInt64(key)
+}
+
+// MustGetFloat32 retrieves a float32 named by key.
+// It panics with the error of GetFloat32E if the value is not present or is not a valid float32.
+func MustGetFloat32(key string) float32 {
+	return Default.MustGetFloat32(key)
+}
+
+// MustGetFloat64 retrieves a float64 named by key.
+// It panics with the error of GetFloat64E if the value is not present or is not a valid float64.
+func MustGetFloat64(key string) float64 {
+	return Default.MustGetFloat64(key)
+}
+
+// MustGetBool retrieves a bool named by key.
+// It panics with the error of GetBoolE if the value is not present or is not a valid bool.
+func MustGetBool(key string) bool {
+	return Default.MustGetBool(key)
+}
+
+// MustGetDuration retrieves a time.Duration named by key.
+// It panics with the error of GetDurationE if the value is not present or is not a valid time.Duration.
+func MustGetDuration(key string) time.Duration {
+	return Default.MustGetDuration(key)
+}
+
+// MustParse retrieves a T named by key.
+// It panics with the error of Parse if the value is not present, is not a valid T or violates any of rules.
+func MustParse[T any](key string, rules ...Rule[T]) T {
+	return MustParseIn(Default, key, rules...)
+}
+
+// MustParseIn retrieves a T named by key from s.
+// It panics with the error of ParseIn if the value is not present, is not a valid T or violates any of rules.
+func MustParseIn[T any](s Scope, key string, rules ...Rule[T]) T {
+	v, err := ParseIn(s, key, rules...)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
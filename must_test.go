@@ -0,0 +1,19 @@
+package env
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMustGet(t *testing.T) {
+	Default.Source = Map{"PORT": "8080", "BAD": "x", "FOO_PORT": "80"}
+	defer func() { Default.Source = OS }()
+
+	require.Equal(t, 8080, MustGetInt("PORT"))
+	require.Equal(t, uint16(80), Prefix("FOO").MustGetUInt16("PORT"))
+	require.Equal(t, []int{8080}, MustParse[[]int]("PORT"))
+	require.Panics(t, func() { MustGetInt("BAD") })
+	require.Panics(t, func() { MustGetDuration("MISSING") })
+	require.Panics(t, func() { MustParseIn[int](Prefix("FOO"), "MISSING") })
+}
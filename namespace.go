@@ -0,0 +1,87 @@
+package env
+
+import (
+	"strings"
+)
+
+// Case is the case transformation applied by a Namespace to the names of variables.
+type Case int
+
+const (
+	// CaseKeep leaves names unchanged.
+	CaseKeep Case = iota
+	// CaseUpper converts names to upper case, e.g. APP_DB_HOST.
+	CaseUpper
+	// CaseLower converts names to lower case, e.g. app.db.host.
+	CaseLower
+)
+
+func (c Case) apply(s string) string {
+	switch c {
+	case CaseUpper:
+		return strings.ToUpper(s)
+	case CaseLower:
+		return strings.ToLower(s)
+	}
+	return s
+}
+
+// Namespace is a hierarchical prefix with a configurable separator and case, for naming schemes a Prefix cannot express,
+// e.g. app.db.host or APP__DB__HOST. It implements Scope, so its values can be retrieved with the generic getters.
+// The zero value has no prefix, joins names with an underscore and retrieves values from Default.
+//
+//	db := env.Namespace{Separator: "__", Case: env.CaseUpper}.Sub("app").Sub("db")
+//	host := env.GetAsIn[string](db, "host") // APP__DB__HOST
+type Namespace struct {
+	Env       *Env     // the Env values are retrieved from; Default if nil
+	Separator string   // the separator between names; an underscore if empty
+	Case      Case     // the case transformation applied to names
+	Path      []string // the names of the enclosing namespaces, outermost first
+}
+
+// NewNamespace returns a Namespace nested under the names in path, joined with an underscore, like a Prefix.
+func NewNamespace(path ...string) Namespace {
+	return Namespace{Path: path}
+}
+
+// Sub returns the Namespace nested under n named by name.
+func (n Namespace) Sub(name string) Namespace {
+	n.Path = append(append([]string(nil), n.Path...), name)
+	return n
+}
+
+// Key returns the name of the variable retrieved for key.
+func (n Namespace) Key(key string) string {
+	sep := n.Separator
+	if sep == "" {
+		sep = "_"
+	}
+	names := append(append([]string(nil), n.Path...), key)
+	return n.Case.apply(strings.Join(names, sep))
+}
+
+// Get retrieves the value named by key.
+func (n Namespace) Get(key string) string {
+	return n.env().Get(n.Key(key))
+}
+
+// GetD attempts to retrieve a string named by key. If the value is not present, def is returned instead.
+func (n Namespace) GetD(key string, def string) string {
+	return n.env().GetD(n.Key(key), def)
+}
+
+// Lookup retrieves the value named by key and reports whether it is present.
+func (n Namespace) Lookup(key string) (string, bool) {
+	return n.env().Lookup(n.Key(key))
+}
+
+func (n Namespace) scope(key string) (*Env, string) {
+	return n.env(), n.Key(key)
+}
+
+func (n Namespace) env() *Env {
+	if n.Env == nil {
+		return Default
+	}
+	return n.Env
+}
@@ -0,0 +1,50 @@
+package env
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrefix_Sub(t *testing.T) {
+	p := Prefix("APP").Sub("DB")
+	require.Equal(t, Prefix("APP_DB"), p)
+	require.Equal(t, "APP_DB_HOST", p.Key("HOST"))
+	require.Equal(t, "APP_PORT", Prefix("APP").Key("PORT"))
+}
+
+func TestNamespace(t *testing.T) {
+	e := New(Map{
+		"APP_DB_HOST":   "underscore",
+		"app.db.host":   "dotted",
+		"APP__DB__HOST": "double",
+		"APP__DB__PORT": "5432",
+	})
+
+	underscore := NewNamespace("APP").Sub("DB")
+	underscore.Env = e
+	require.Equal(t, "APP_DB_HOST", underscore.Key("HOST"))
+	require.Equal(t, "underscore", underscore.Get("HOST"))
+
+	dotted := Namespace{Env: e, Separator: ".", Case: CaseLower}.Sub("APP").Sub("DB")
+	require.Equal(t, "app.db.host", dotted.Key("HOST"))
+	require.Equal(t, "dotted", dotted.Get("HOST"))
+
+	double := Namespace{Env: e, Separator: "__", Case: CaseUpper}.Sub("app").Sub("db")
+	require.Equal(t, "double", double.Get("host"))
+	require.Equal(t, "fallback", double.GetD("user", "fallback"))
+	_, ok := double.Lookup("user")
+	require.False(t, ok)
+
+	port, err := ParseIn[uint16](double, "port")
+	require.NoError(t, err)
+	require.Equal(t, uint16(5432), port)
+}
+
+func TestNamespace_Sub(t *testing.T) {
+	parent := NewNamespace("APP")
+	a, b := parent.Sub("A"), parent.Sub("B")
+	require.Equal(t, "APP_A_KEY", a.Key("KEY"))
+	require.Equal(t, "APP_B_KEY", b.Key("KEY"))
+	require.Equal(t, "KEY", Namespace{}.Key("KEY"))
+}
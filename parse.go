@@ -0,0 +1,190 @@
+package env
+
+import (
+	"encoding"
+	"errors"
+	"flag"
+	"reflect"
+	"sync"
+)
+
+var (
+	errUnsupportedType = errors.New("unsupported type")
+
+	textUnmarshalerType = typeOf[encoding.TextUnmarshaler]()
+	flagValueType       = typeOf[flag.Value]()
+)
+
+// parseFunc parses s as a value of the type it is registered for.
+type parseFunc func(s string) (interface{}, error)
+
+var (
+	parsersMu sync.RWMutex
+	// parsers holds the parseFunc of each registered type.
+	parsers = map[reflect.Type]parseFunc{}
+)
+
+func init() {
+	RegisterParser(func(s string) (string, error) { return s, nil })
+	RegisterParser(parseInt)
+	RegisterParser(parseInt8)
+	RegisterParser(parseInt16)
+	RegisterParser(parseInt32)
+	RegisterParser(parseInt64)
+	RegisterParser(parseUInt)
+	RegisterParser(parseUInt8)
+	RegisterParser(parseUInt16)
+	RegisterParser(parseUInt32)
+	RegisterParser(parseUInt64)
+	RegisterParser(parseFloat32)
+	RegisterParser(parseFloat64)
+	RegisterParser(parseBool)
+	RegisterParser(parseDuration)
+}
+
+// RegisterParser registers parse as the parser of values of type T, replacing any previously registered parser.
+// Registered types are supported by the generic getters, e.g. Parse, and by Unmarshal, including as the elements of
+// slices and maps.
+//
+// Types implementing encoding.TextUnmarshaler or flag.Value through a pointer receiver do not need to be registered.
+func RegisterParser[T any](parse func(s string) (T, error)) {
+	parsersMu.Lock()
+	defer parsersMu.Unlock()
+
+	parsers[typeOf[T]()] = func(s string) (interface{}, error) {
+		return parse(s)
+	}
+}
+
+// parserFor returns the parseFunc of t, which is either registered or derived from the methods of t.
+func parserFor(t reflect.Type) (parseFunc, bool) {
+	parsersMu.RLock()
+	parse, ok := parsers[t]
+	parsersMu.RUnlock()
+	if ok {
+		return parse, true
+	}
+
+	pt := reflect.PointerTo(t)
+	switch {
+	case pt.Implements(textUnmarshalerType):
+		return func(s string) (interface{}, error) {
+			v := reflect.New(t)
+			err := v.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+			return v.Elem().Interface(), err
+		}, true
+	case pt.Implements(flagValueType):
+		return func(s string) (interface{}, error) {
+			v := reflect.New(t)
+			err := v.Interface().(flag.Value).Set(s)
+			return v.Elem().Interface(), err
+		}, true
+	}
+	return nil, false
+}
+
+func typeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// kindTypes maps each basic kind to the type whose parseFunc is used for named types of that kind, e.g. type Port int.
+var kindTypes = map[reflect.Kind]reflect.Type{
+	reflect.String:  typeOf[string](),
+	reflect.Int:     typeOf[int](),
+	reflect.Int8:    typeOf[int8](),
+	reflect.Int16:   typeOf[int16](),
+	reflect.Int32:   typeOf[int32](),
+	reflect.Int64:   typeOf[int64](),
+	reflect.Uint:    typeOf[uint](),
+	reflect.Uint8:   typeOf[uint8](),
+	reflect.Uint16:  typeOf[uint16](),
+	reflect.Uint32:  typeOf[uint32](),
+	reflect.Uint64:  typeOf[uint64](),
+	reflect.Float32: typeOf[float32](),
+	reflect.Float64: typeOf[float64](),
+	reflect.Bool:    typeOf[bool](),
+}
+
+// parse parses s as a value of type t.
+// Slices are split on the ListSeparator, and maps with string keys are split on the MapSeparator and KeyValueSeparator,
+// with each element parsed as the element type of t.
+func (e *Env) parse(t reflect.Type, s string) (reflect.Value, error) {
+	if parse, ok := parserFor(t); ok {
+		v, err := parse(s)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(v), nil
+	}
+
+	switch t.Kind() {
+	case reflect.Slice:
+		return e.parseSlice(t, s)
+	case reflect.Map:
+		return e.parseMap(t, s)
+	}
+
+	if kt, ok := kindTypes[t.Kind()]; ok {
+		v, err := e.parse(kt, s)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return v.Convert(t), nil
+	}
+	return reflect.Value{}, errUnsupportedType
+}
+
+func (e *Env) parseSlice(t reflect.Type, s string) (reflect.Value, error) {
+	if !scalar(t.Elem()) {
+		return reflect.Value{}, errUnsupportedType
+	}
+
+	elems, err := splitList(s, e.listSeparator())
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	v := reflect.MakeSlice(t, len(elems), len(elems))
+	for i, elem := range elems {
+		ev, err := e.parse(t.Elem(), elem)
+		if err != nil {
+			return reflect.Value{}, &ElementError{Index: i, Value: elem, Err: unwrapNumError(err)}
+		}
+		v.Index(i).Set(ev)
+	}
+	return v, nil
+}
+
+func (e *Env) parseMap(t reflect.Type, s string) (reflect.Value, error) {
+	if t.Key().Kind() != reflect.String || !scalar(t.Elem()) {
+		return reflect.Value{}, errUnsupportedType
+	}
+
+	entries, err := splitMap(s, e.mapSeparator(), e.keyValueSeparator())
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	v := reflect.MakeMapWithSize(t, len(entries))
+	for i, entry := range entries {
+		ev, err := e.parse(t.Elem(), entry.value)
+		if err != nil {
+			return reflect.Value{}, &ElementError{Index: i, Value: entry.raw, Err: unwrapNumError(err)}
+		}
+		v.SetMapIndex(reflect.ValueOf(entry.key).Convert(t.Key()), ev)
+	}
+	return v, nil
+}
+
+// hasParser reports whether t is registered or derives its parseFunc from its methods.
+func hasParser(t reflect.Type) bool {
+	_, ok := parserFor(t)
+	return ok
+}
+
+// scalar reports whether t can be parsed from a single element of a list or map value.
+func scalar(t reflect.Type) bool {
+	if hasParser(t) {
+		return true
+	}
+	_, ok := kindTypes[t.Kind()]
+	return ok
+}
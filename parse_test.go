@@ -0,0 +1,103 @@
+package env
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type parseRegion string
+
+type parseLevel int
+
+func (l *parseLevel) UnmarshalText(text []byte) error {
+	switch strings.ToLower(string(text)) {
+	case "debug":
+		*l = 0
+	case "info":
+		*l = 1
+	default:
+		return fmt.Errorf("unknown level %q", text)
+	}
+	return nil
+}
+
+type parseTier struct {
+	name string
+}
+
+func (t *parseTier) String() string {
+	return t.name
+}
+
+func (t *parseTier) Set(s string) error {
+	if s != "gold" && s != "silver" {
+		return errors.New("unknown tier")
+	}
+	t.name = s
+	return nil
+}
+
+func TestRegisterParser(t *testing.T) {
+	RegisterParser(func(s string) (parseRegion, error) {
+		if len(s) != 2 {
+			return "", errors.New("region codes are two letters")
+		}
+		return parseRegion(strings.ToUpper(s)), nil
+	})
+
+	e := New(Map{"REGION": "us", "REGIONS": "us,eu", "BAD": "usa"})
+	require.Equal(t, parseRegion("US"), GetAsIn[parseRegion](e, "REGION"))
+	require.Equal(t, []parseRegion{"US", "EU"}, GetAsIn[[]parseRegion](e, "REGIONS"))
+
+	_, err := ParseIn[parseRegion](e, "BAD")
+	require.EqualError(t, err, `env: BAD: parsing "usa" as env.parseRegion: region codes are two letters`)
+}
+
+func TestParse_TextUnmarshaler(t *testing.T) {
+	e := New(Map{
+		"LEVEL":  "INFO",
+		"BAD":    "trace",
+		"IP":     "10.0.0.1",
+		"TIME":   "2020-01-02T03:04:05Z",
+		"LEVELS": "debug:info",
+	})
+
+	require.Equal(t, parseLevel(1), GetAsIn[parseLevel](e, "LEVEL"))
+	require.Equal(t, net.ParseIP("10.0.0.1"), GetAsIn[net.IP](e, "IP"))
+	require.Equal(t, time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC), GetAsIn[time.Time](e, "TIME"))
+	require.Equal(t, map[string]parseLevel{"debug": 1}, GetAsIn[map[string]parseLevel](e, "LEVELS"))
+
+	_, err := ParseIn[parseLevel](e, "BAD")
+	var parseErr *ParseError
+	require.True(t, errors.As(err, &parseErr))
+}
+
+func TestParse_FlagValue(t *testing.T) {
+	e := New(Map{"TIER": "gold", "BAD": "bronze"})
+
+	require.Equal(t, parseTier{name: "gold"}, GetAsIn[parseTier](e, "TIER"))
+	require.Equal(t, parseTier{name: "silver"}, GetOrIn(e, "BAD", parseTier{name: "silver"}))
+}
+
+func TestUnmarshal_CustomTypes(t *testing.T) {
+	t.Setenv("CUSTOM_LEVEL", "debug")
+	t.Setenv("CUSTOM_TIER", "silver")
+	t.Setenv("CUSTOM_SINCE", "2020-01-02T03:04:05Z")
+
+	var cfg struct {
+		Level parseLevel `env:"LEVEL"`
+		Tier  parseTier  `env:"TIER"`
+		Since time.Time  `env:"SINCE"`
+	}
+	cfg.Level = 1
+	require.NoError(t, Prefix("CUSTOM").Unmarshal(&cfg))
+	require.Equal(t, parseLevel(0), cfg.Level)
+	require.Equal(t, parseTier{name: "silver"}, cfg.Tier)
+	require.Equal(t, time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC), cfg.Since)
+}
@@ -9,7 +9,7 @@ import (
 // For example, Prefix("FOO").GetString("BAR") would return the value of FOO_BAR.
 type Prefix string
 
-// Get wraps os.Getenv.
+// Get retrieves the value named by key.
 func (p Prefix) Get(key string) string {
 	return Get(p.format(key))
 }
@@ -19,7 +19,7 @@ func (p Prefix) GetD(key string, def string) string {
 	return GetD(p.format(key), def)
 }
 
-// Lookup wraps os.LookupEnv.
+// Lookup retrieves the value named by key and reports whether it is present.
 func (p Prefix) Lookup(key string) (string, bool) {
 	return Lookup(p.format(key))
 }
@@ -36,6 +36,12 @@ func (p Prefix) GetStringD(key string, def string) string {
 	return GetStringD(p.format(key), def)
 }
 
+// GetStringE retrieves a string named by key.
+// A *NotSetError is returned if the value is not present.
+func (p Prefix) GetStringE(key string) (string, error) {
+	return GetStringE(p.format(key))
+}
+
 // GetInt retrieves an int named by key.
 // int(0) is returned if the value does not exist or is not a valid int.
 func (p Prefix) GetInt(key string) int {
@@ -47,6 +53,12 @@ func (p Prefix) GetIntD(key string, def int) int {
 	return GetIntD(p.format(key), def)
 }
 
+// GetIntE retrieves an int named by key.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it is not a valid int.
+func (p Prefix) GetIntE(key string) (int, error) {
+	return GetIntE(p.format(key))
+}
+
 // GetInt8 retrieves an int8 named by key.
 // int8(0) is returned if the value does not exist or is not a valid int8.
 func (p Prefix) GetInt8(key string) int8 {
@@ -58,6 +70,12 @@ func (p Prefix) GetInt8D(key string, def int8) int8 {
 	return GetInt8D(p.format(key), def)
 }
 
+// GetInt8E retrieves an int8 named by key.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it is not a valid int8.
+func (p Prefix) GetInt8E(key string) (int8, error) {
+	return GetInt8E(p.format(key))
+}
+
 // GetInt16 retrieves an int16 named by key.
 // int16(0) is returned if the value does not exist or is not a valid int16.
 func (p Prefix) GetInt16(key string) int16 {
@@ -69,6 +87,12 @@ func (p Prefix) GetInt16D(key string, def int16) int16 {
 	return GetInt16D(p.format(key), def)
 }
 
+// GetInt16E retrieves an int16 named by key.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it is not a valid int16.
+func (p Prefix) GetInt16E(key string) (int16, error) {
+	return GetInt16E(p.format(key))
+}
+
 // GetInt32 retrieves an int32 named by key.
 // int32(0) is returned if the value does not exist or is not a valid int32.
 func (p Prefix) GetInt32(key string) int32 {
@@ -80,6 +104,12 @@ func (p Prefix) GetInt32D(key string, def int32) int32 {
 	return GetInt32D(p.format(key), def)
 }
 
+// GetInt32E retrieves an int32 named by key.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it is not a valid int32.
+func (p Prefix) GetInt32E(key string) (int32, error) {
+	return GetInt32E(p.format(key))
+}
+
 // GetInt64 retrieves an int64 named by key.
 // int64(0) is returned if the value does not exist or is not a valid int64.
 func (p Prefix) GetInt64(key string) int64 {
@@ -91,6 +121,12 @@ func (p Prefix) GetInt64D(key string, def int64) int64 {
 	return GetInt64D(p.format(key), def)
 }
 
+// GetInt64E retrieves an int64 named by key.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it is not a valid int64.
+func (p Prefix) GetInt64E(key string) (int64, error) {
+	return GetInt64E(p.format(key))
+}
+
 // GetUInt retrieves an uint named by key.
 // uint(0) is returned if the value does not exist or is not a valid uint.
 func (p Prefix) GetUInt(key string) uint {
@@ -102,6 +138,12 @@ func (p Prefix) GetUIntD(key string, def uint) uint {
 	return GetUIntD(p.format(key), def)
 }
 
+// GetUIntE retrieves an uint named by key.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it is not a valid uint.
+func (p Prefix) GetUIntE(key string) (uint, error) {
+	return GetUIntE(p.format(key))
+}
+
 // GetUInt8 retrieves an uint8 named by key.
 // uint8(0) is returned if the value does not exist or is not a valid uint8.
 func (p Prefix) GetUInt8(key string) uint8 {
@@ -113,6 +155,12 @@ func (p Prefix) GetUInt8D(key string, def uint8) uint8 {
 	return GetUInt8D(p.format(key), def)
 }
 
+// GetUInt8E retrieves an uint8 named by key.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it is not a valid uint8.
+func (p Prefix) GetUInt8E(key string) (uint8, error) {
+	return GetUInt8E(p.format(key))
+}
+
 // GetUInt16 retrieves an uint16 named by key.
 // uint16(0) is returned if the value does not exist or is not a valid uint16.
 func (p Prefix) GetUInt16(key string) uint16 {
@@ -124,6 +172,12 @@ func (p Prefix) GetUInt16D(key string, def uint16) uint16 {
 	return GetUInt16D(p.format(key), def)
 }
 
+// GetUInt16E retrieves an uint16 named by key.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it is not a valid uint16.
+func (p Prefix) GetUInt16E(key string) (uint16, error) {
+	return GetUInt16E(p.format(key))
+}
+
 // GetUInt32 retrieves an uint32 named by key.
 // uint32(0) is returned if the value does not exist or is not a valid uint32.
 func (p Prefix) GetUInt32(key string) uint32 {
@@ -135,6 +189,12 @@ func (p Prefix) GetUInt32D(key string, def uint32) uint32 {
 	return GetUInt32D(p.format(key), def)
 }
 
+// GetUInt32E retrieves an uint32 named by key.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it is not a valid uint32.
+func (p Prefix) GetUInt32E(key string) (uint32, error) {
+	return GetUInt32E(p.format(key))
+}
+
 // GetUInt64 retrieves an uint64 named by key.
 // uint64(0) is returned if the value does not exist or is not a valid uint64.
 func (p Prefix) GetUInt64(key string) uint64 {
@@ -146,6 +206,12 @@ func (p Prefix) GetUInt64D(key string, def uint64) uint64 {
 	return GetUInt64D(p.format(key), def)
 }
 
+// GetUInt64E retrieves an uint64 named by key.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it is not a valid uint64.
+func (p Prefix) GetUInt64E(key string) (uint64, error) {
+	return GetUInt64E(p.format(key))
+}
+
 // GetFloat32 retrieves a float32 named by key.
 // float32(0) is returned if the value does not exist or is not a valid float32.
 func (p Prefix) GetFloat32(key string) float32 {
@@ -157,6 +223,12 @@ func (p Prefix) GetFloat32D(key string, def float32) float32 {
 	return GetFloat32D(p.format(key), def)
 }
 
+// GetFloat32E retrieves a float32 named by key.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it is not a valid float32.
+func (p Prefix) GetFloat32E(key string) (float32, error) {
+	return GetFloat32E(p.format(key))
+}
+
 // GetFloat64 retrieves a float64 named by key.
 // float64(0) is returned if the value does not exist or is not a valid float64.
 func (p Prefix) GetFloat64(key string) float64 {
@@ -168,6 +240,12 @@ func (p Prefix) GetFloat64D(key string, def float64) float64 {
 	return GetFloat64D(p.format(key), def)
 }
 
+// GetFloat64E retrieves a float64 named by key.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it is not a valid float64.
+func (p Prefix) GetFloat64E(key string) (float64, error) {
+	return GetFloat64E(p.format(key))
+}
+
 // GetBool retrieves a bool named by key.
 // bool(false) is returned if the value does not exist or is not a valid bool.
 func (p Prefix) GetBool(key string) bool {
@@ -179,6 +257,12 @@ func (p Prefix) GetBoolD(key string, def bool) bool {
 	return GetBoolD(p.format(key), def)
 }
 
+// GetBoolE retrieves a bool named by key.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it is not a valid bool.
+func (p Prefix) GetBoolE(key string) (bool, error) {
+	return GetBoolE(p.format(key))
+}
+
 // GetDuration retrieves a time.Duration named by key.
 // time.Duration(0) is returned if the value does not exist or is not parsed as a valid time.Duration.
 func (p Prefix) GetDuration(key string) time.Duration {
@@ -190,6 +274,563 @@ func (p Prefix) GetDurationD(key string, def time.Duration) time.Duration {
 	return GetDurationD(p.format(key), def)
 }
 
+// GetDurationE retrieves a time.Duration named by key.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it is not a valid time.Duration.
+func (p Prefix) GetDurationE(key string) (time.Duration, error) {
+	return GetDurationE(p.format(key))
+}
+
+// GetStrings retrieves a []string named by key, splitting the value on the ListSeparator.
+// A nil slice is returned if the value does not exist or contains an unterminated quoted element.
+func (p Prefix) GetStrings(key string) []string {
+	return GetStrings(p.format(key))
+}
+
+// GetStringsD attempts to retrieve a []string named by key. If the value is not present or is not valid, def is returned instead.
+func (p Prefix) GetStringsD(key string, def []string) []string {
+	return GetStringsD(p.format(key), def)
+}
+
+// GetStringsE retrieves a []string named by key, splitting the value on the ListSeparator.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it contains a malformed quoted element.
+func (p Prefix) GetStringsE(key string) ([]string, error) {
+	return GetStringsE(p.format(key))
+}
+
+// GetInts retrieves a []int named by key, splitting the value on the ListSeparator.
+// A nil slice is returned if the value does not exist or any element is not a valid int.
+func (p Prefix) GetInts(key string) []int {
+	return GetInts(p.format(key))
+}
+
+// GetIntsD attempts to retrieve a []int named by key. If the value is not present or is not valid, def is returned instead.
+func (p Prefix) GetIntsD(key string, def []int) []int {
+	return GetIntsD(p.format(key), def)
+}
+
+// GetIntsE retrieves a []int named by key, splitting the value on the ListSeparator.
+// A *NotSetError is returned if the value is not present, or a *ParseError if any element is not a valid int.
+func (p Prefix) GetIntsE(key string) ([]int, error) {
+	return GetIntsE(p.format(key))
+}
+
+// GetInt64s retrieves a []int64 named by key, splitting the value on the ListSeparator.
+// A nil slice is returned if the value does not exist or any element is not a valid int64.
+func (p Prefix) GetInt64s(key string) []int64 {
+	return GetInt64s(p.format(key))
+}
+
+// GetInt64sD attempts to retrieve a []int64 named by key. If the value is not present or is not valid, def is returned instead.
+func (p Prefix) GetInt64sD(key string, def []int64) []int64 {
+	return GetInt64sD(p.format(key), def)
+}
+
+// GetInt64sE retrieves a []int64 named by key, splitting the value on the ListSeparator.
+// A *NotSetError is returned if the value is not present, or a *ParseError if any element is not a valid int64.
+func (p Prefix) GetInt64sE(key string) ([]int64, error) {
+	return GetInt64sE(p.format(key))
+}
+
+// GetUInts retrieves a []uint named by key, splitting the value on the ListSeparator.
+// A nil slice is returned if the value does not exist or any element is not a valid uint.
+func (p Prefix) GetUInts(key string) []uint {
+	return GetUInts(p.format(key))
+}
+
+// GetUIntsD attempts to retrieve a []uint named by key. If the value is not present or is not valid, def is returned instead.
+func (p Prefix) GetUIntsD(key string, def []uint) []uint {
+	return GetUIntsD(p.format(key), def)
+}
+
+// GetUIntsE retrieves a []uint named by key, splitting the value on the ListSeparator.
+// A *NotSetError is returned if the value is not present, or a *ParseError if any element is not a valid uint.
+func (p Prefix) GetUIntsE(key string) ([]uint, error) {
+	return GetUIntsE(p.format(key))
+}
+
+// GetUInt64s retrieves a []uint64 named by key, splitting the value on the ListSeparator.
+// A nil slice is returned if the value does not exist or any element is not a valid uint64.
+func (p Prefix) GetUInt64s(key string) []uint64 {
+	return GetUInt64s(p.format(key))
+}
+
+// GetUInt64sD attempts to retrieve a []uint64 named by key. If the value is not present or is not valid, def is returned instead.
+func (p Prefix) GetUInt64sD(key string, def []uint64) []uint64 {
+	return GetUInt64sD(p.format(key), def)
+}
+
+// GetUInt64sE retrieves a []uint64 named by key, splitting the value on the ListSeparator.
+// A *NotSetError is returned if the value is not present, or a *ParseError if any element is not a valid uint64.
+func (p Prefix) GetUInt64sE(key string) ([]uint64, error) {
+	return GetUInt64sE(p.format(key))
+}
+
+// GetFloat64s retrieves a []float64 named by key, splitting the value on the ListSeparator.
+// A nil slice is returned if the value does not exist or any element is not a valid float64.
+func (p Prefix) GetFloat64s(key string) []float64 {
+	return GetFloat64s(p.format(key))
+}
+
+// GetFloat64sD attempts to retrieve a []float64 named by key. If the value is not present or is not valid, def is returned instead.
+func (p Prefix) GetFloat64sD(key string, def []float64) []float64 {
+	return GetFloat64sD(p.format(key), def)
+}
+
+// GetFloat64sE retrieves a []float64 named by key, splitting the value on the ListSeparator.
+// A *NotSetError is returned if the value is not present, or a *ParseError if any element is not a valid float64.
+func (p Prefix) GetFloat64sE(key string) ([]float64, error) {
+	return GetFloat64sE(p.format(key))
+}
+
+// GetBools retrieves a []bool named by key, splitting the value on the ListSeparator.
+// A nil slice is returned if the value does not exist or any element is not a valid bool.
+func (p Prefix) GetBools(key string) []bool {
+	return GetBools(p.format(key))
+}
+
+// GetBoolsD attempts to retrieve a []bool named by key. If the value is not present or is not valid, def is returned instead.
+func (p Prefix) GetBoolsD(key string, def []bool) []bool {
+	return GetBoolsD(p.format(key), def)
+}
+
+// GetBoolsE retrieves a []bool named by key, splitting the value on the ListSeparator.
+// A *NotSetError is returned if the value is not present, or a *ParseError if any element is not a valid bool.
+func (p Prefix) GetBoolsE(key string) ([]bool, error) {
+	return GetBoolsE(p.format(key))
+}
+
+// GetDurations retrieves a []time.Duration named by key, splitting the value on the ListSeparator.
+// A nil slice is returned if the value does not exist or any element is not a valid time.Duration.
+func (p Prefix) GetDurations(key string) []time.Duration {
+	return GetDurations(p.format(key))
+}
+
+// GetDurationsD attempts to retrieve a []time.Duration named by key. If the value is not present or is not valid, def is returned instead.
+func (p Prefix) GetDurationsD(key string, def []time.Duration) []time.Duration {
+	return GetDurationsD(p.format(key), def)
+}
+
+// GetDurationsE retrieves a []time.Duration named by key, splitting the value on the ListSeparator.
+// A *NotSetError is returned if the value is not present, or a *ParseError if any element is not a valid time.Duration.
+func (p Prefix) GetDurationsE(key string) ([]time.Duration, error) {
+	return GetDurationsE(p.format(key))
+}
+
+// GetStringMap retrieves a map[string]string named by key, splitting the value into entries on the MapSeparator and each entry into
+// a key and value on the KeyValueSeparator.
+// A nil map is returned if the value does not exist or is malformed.
+func (p Prefix) GetStringMap(key string) map[string]string {
+	return GetStringMap(p.format(key))
+}
+
+// GetStringMapD attempts to retrieve a map[string]string named by key. If the value is not present or is not valid, def is returned instead.
+func (p Prefix) GetStringMapD(key string, def map[string]string) map[string]string {
+	return GetStringMapD(p.format(key), def)
+}
+
+// GetStringMapE retrieves a map[string]string named by key, splitting the value into entries on the MapSeparator and each entry into
+// a key and value on the KeyValueSeparator.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it is malformed or contains
+// a duplicate key.
+func (p Prefix) GetStringMapE(key string) (map[string]string, error) {
+	return GetStringMapE(p.format(key))
+}
+
+// GetIntMap retrieves a map[string]int named by key, splitting the value into entries on the MapSeparator and each entry into
+// a key and value on the KeyValueSeparator.
+// A nil map is returned if the value does not exist, is malformed or any value is not a valid int.
+func (p Prefix) GetIntMap(key string) map[string]int {
+	return GetIntMap(p.format(key))
+}
+
+// GetIntMapD attempts to retrieve a map[string]int named by key. If the value is not present or is not valid, def is returned instead.
+func (p Prefix) GetIntMapD(key string, def map[string]int) map[string]int {
+	return GetIntMapD(p.format(key), def)
+}
+
+// GetIntMapE retrieves a map[string]int named by key, splitting the value into entries on the MapSeparator and each entry into
+// a key and value on the KeyValueSeparator.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it is malformed, contains
+// a duplicate key or any value is not a valid int.
+func (p Prefix) GetIntMapE(key string) (map[string]int, error) {
+	return GetIntMapE(p.format(key))
+}
+
+// GetInt64Map retrieves a map[string]int64 named by key, splitting the value into entries on the MapSeparator and each entry into
+// a key and value on the KeyValueSeparator.
+// A nil map is returned if the value does not exist, is malformed or any value is not a valid int64.
+func (p Prefix) GetInt64Map(key string) map[string]int64 {
+	return GetInt64Map(p.format(key))
+}
+
+// GetInt64MapD attempts to retrieve a map[string]int64 named by key. If the value is not present or is not valid, def is returned instead.
+func (p Prefix) GetInt64MapD(key string, def map[string]int64) map[string]int64 {
+	return GetInt64MapD(p.format(key), def)
+}
+
+// GetInt64MapE retrieves a map[string]int64 named by key, splitting the value into entries on the MapSeparator and each entry into
+// a key and value on the KeyValueSeparator.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it is malformed, contains
+// a duplicate key or any value is not a valid int64.
+func (p Prefix) GetInt64MapE(key string) (map[string]int64, error) {
+	return GetInt64MapE(p.format(key))
+}
+
+// GetUInt64Map retrieves a map[string]uint64 named by key, splitting the value into entries on the MapSeparator and each entry into
+// a key and value on the KeyValueSeparator.
+// A nil map is returned if the value does not exist, is malformed or any value is not a valid uint64.
+func (p Prefix) GetUInt64Map(key string) map[string]uint64 {
+	return GetUInt64Map(p.format(key))
+}
+
+// GetUInt64MapD attempts to retrieve a map[string]uint64 named by key. If the value is not present or is not valid, def is returned instead.
+func (p Prefix) GetUInt64MapD(key string, def map[string]uint64) map[string]uint64 {
+	return GetUInt64MapD(p.format(key), def)
+}
+
+// GetUInt64MapE retrieves a map[string]uint64 named by key, splitting the value into entries on the MapSeparator and each entry into
+// a key and value on the KeyValueSeparator.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it is malformed, contains
+// a duplicate key or any value is not a valid uint64.
+func (p Prefix) GetUInt64MapE(key string) (map[string]uint64, error) {
+	return GetUInt64MapE(p.format(key))
+}
+
+// GetFloat64Map retrieves a map[string]float64 named by key, splitting the value into entries on the MapSeparator and each entry into
+// a key and value on the KeyValueSeparator.
+// A nil map is returned if the value does not exist, is malformed or any value is not a valid float64.
+func (p Prefix) GetFloat64Map(key string) map[string]float64 {
+	return GetFloat64Map(p.format(key))
+}
+
+// GetFloat64MapD attempts to retrieve a map[string]float64 named by key. If the value is not present or is not valid, def is returned instead.
+func (p Prefix) GetFloat64MapD(key string, def map[string]float64) map[string]float64 {
+	return GetFloat64MapD(p.format(key), def)
+}
+
+// GetFloat64MapE retrieves a map[string]float64 named by key, splitting the value into entries on the MapSeparator and each entry into
+// a key and value on the KeyValueSeparator.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it is malformed, contains
+// a duplicate key or any value is not a valid float64.
+func (p Prefix) GetFloat64MapE(key string) (map[string]float64, error) {
+	return GetFloat64MapE(p.format(key))
+}
+
+// GetBoolMap retrieves a map[string]bool named by key, splitting the value into entries on the MapSeparator and each entry into
+// a key and value on the KeyValueSeparator.
+// A nil map is returned if the value does not exist, is malformed or any value is not a valid bool.
+func (p Prefix) GetBoolMap(key string) map[string]bool {
+	return GetBoolMap(p.format(key))
+}
+
+// GetBoolMapD attempts to retrieve a map[string]bool named by key. If the value is not present or is not valid, def is returned instead.
+func (p Prefix) GetBoolMapD(key string, def map[string]bool) map[string]bool {
+	return GetBoolMapD(p.format(key), def)
+}
+
+// GetBoolMapE retrieves a map[string]bool named by key, splitting the value into entries on the MapSeparator and each entry into
+// a key and value on the KeyValueSeparator.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it is malformed, contains
+// a duplicate key or any value is not a valid bool.
+func (p Prefix) GetBoolMapE(key string) (map[string]bool, error) {
+	return GetBoolMapE(p.format(key))
+}
+
+// GetDurationMap retrieves a map[string]time.Duration named by key, splitting the value into entries on the MapSeparator and each entry into
+// a key and value on the KeyValueSeparator.
+// A nil map is returned if the value does not exist, is malformed or any value is not a valid time.Duration.
+func (p Prefix) GetDurationMap(key string) map[string]time.Duration {
+	return GetDurationMap(p.format(key))
+}
+
+// GetDurationMapD attempts to retrieve a map[string]time.Duration named by key. If the value is not present or is not valid, def is returned instead.
+func (p Prefix) GetDurationMapD(key string, def map[string]time.Duration) map[string]time.Duration {
+	return GetDurationMapD(p.format(key), def)
+}
+
+// GetDurationMapE retrieves a map[string]time.Duration named by key, splitting the value into entries on the MapSeparator and each entry into
+// a key and value on the KeyValueSeparator.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it is malformed, contains
+// a duplicate key or any value is not a valid time.Duration.
+func (p Prefix) GetDurationMapE(key string) (map[string]time.Duration, error) {
+	return GetDurationMapE(p.format(key))
+}
+
+// MustGetString retrieves a string named by key.
+// It panics with the error of GetStringE if the value is not present.
+func (p Prefix) MustGetString(key string) string {
+	return MustGetString(p.format(key))
+}
+
+// MustGetInt retrieves an int named by key.
+// It panics with the error of GetIntE if the value is not present or is not a valid int.
+func (p Prefix) MustGetInt(key string) int {
+	return MustGetInt(p.format(key))
+}
+
+// MustGetInt8 retrieves an int8 named by key.
+// It panics with the error of GetInt8E if the value is not present or is not a valid int8.
+func (p Prefix) MustGetInt8(key string) int8 {
+	return MustGetInt8(p.format(key))
+}
+
+// MustGetInt16 retrieves an int16 named by key.
+// It panics with the error of GetInt16E if the value is not present or is not a valid int16.
+func (p Prefix) MustGetInt16(key string) int16 {
+	return MustGetInt16(p.format(key))
+}
+
+// MustGetInt32 retrieves an int32 named by key.
+// It panics with the error of GetInt32E if the value is not present or is not a valid int32.
+func (p Prefix) MustGetInt32(key string) int32 {
+	return MustGetInt32(p.format(key))
+}
+
+// MustGetInt64 retrieves an int64 named by key.
+// It panics with the error of GetInt64E if the value is not present or is not a valid int64.
+func (p Prefix) MustGetInt64(key string) int64 {
+	return MustGetInt64(p.format(key))
+}
+
+// MustGetUInt retrieves an uint named by key.
+// It panics with the error of GetUIntE if the value is not present or is not a valid uint.
+func (p Prefix) MustGetUInt(key string) uint {
+	return MustGetUInt(p.format(key))
+}
+
+// MustGetUInt8 retrieves an uint8 named by key.
+// It panics with the error of GetUInt8E if the value is not present or is not a valid uint8.
+func (p Prefix) MustGetUInt8(key string) uint8 {
+	return MustGetUInt8(p.format(key))
+}
+
+// MustGetUInt16 retrieves an uint16 named by key.
+// It panics with the error of GetUInt16E if the value is not present or is not a valid uint16.
+func (p Prefix) MustGetUInt16(key string) uint16 {
+	return MustGetUInt16(p.format(key))
+}
+
+// MustGetUInt32 retrieves an uint32 named by key.
+// It panics with the error of GetUInt32E if the value is not present or is not a valid uint32.
+func (p Prefix) MustGetUInt32(key string) uint32 {
+	return MustGetUInt32(p.format(key))
+}
+
+// MustGetUInt64 retrieves an uint64 named by key.
+// It panics with the error of GetUInt64E if the value is not present or is not a valid uint64.
+func (p Prefix) MustGetUInt64(key string) uint64 {
+	return MustGetUInt64(p.format(key))
+}
+
+// MustGetFloat32 retrieves a float32 named by key.
+// It panics with the error of GetFloat32E if the value is not present or is not a valid float32.
+func (p Prefix) MustGetFloat32(key string) float32 {
+	return MustGetFloat32(p.format(key))
+}
+
+// MustGetFloat64 retrieves a float64 named by key.
+// It panics with the error of GetFloat64E if the value is not present or is not a valid float64.
+func (p Prefix) MustGetFloat64(key string) float64 {
+	return MustGetFloat64(p.format(key))
+}
+
+// MustGetBool retrieves a bool named by key.
+// It panics with the error of GetBoolE if the value is not present or is not a valid bool.
+func (p Prefix) MustGetBool(key string) bool {
+	return MustGetBool(p.format(key))
+}
+
+// MustGetDuration retrieves a time.Duration named by key.
+// It panics with the error of GetDurationE if the value is not present or is not a valid time.Duration.
+func (p Prefix) MustGetDuration(key string) time.Duration {
+	return MustGetDuration(p.format(key))
+}
+
+// GetSecret retrieves a Secret named by key.
+// An empty Secret is returned if the value does not exist.
+func (p Prefix) GetSecret(key string) Secret {
+	return GetSecret(p.format(key))
+}
+
+// GetSecretD attempts to retrieve a Secret named by key. If the value is not present, def is returned instead.
+func (p Prefix) GetSecretD(key string, def Secret) Secret {
+	return GetSecretD(p.format(key), def)
+}
+
+// GetSecretE retrieves a Secret named by key.
+// A *NotSetError is returned if the value is not present.
+func (p Prefix) GetSecretE(key string) (Secret, error) {
+	return GetSecretE(p.format(key))
+}
+
+// Declare declares vars in DefaultRegistry, with the name of each prefixed by p.
+func (p Prefix) Declare(vars ...Var) {
+	DefaultRegistry.DeclarePrefix(p, vars...)
+}
+
+// DeclareStruct declares a variable in DefaultRegistry for each tagged field of the struct pointed to by v, with the
+// keys of the fields prefixed by p. See Registry.DeclareStruct for details.
+func (p Prefix) DeclareStruct(v interface{}) error {
+	return DefaultRegistry.DeclareStruct(p, v)
+}
+
+// Unmarshal populates the struct pointed to by v with the values named by its `env` field tags.
+// See Unmarshal for details on how fields are resolved.
+func (p Prefix) Unmarshal(v interface{}) error {
+	return Default.unmarshal(v, p)
+}
+
+// Which returns the name of the Layer the value named by key is retrieved from by Default.
+func (p Prefix) Which(key string) (string, bool) {
+	return Which(p.format(key))
+}
+
+// Keys returns the names of the variables with the prefix p, with the prefix removed, in sorted order.
+func (p Prefix) Keys() []string {
+	return Default.PrefixKeys(p)
+}
+
+// Map returns the values of the variables with the prefix p, keyed by their names with the prefix removed.
+func (p Prefix) Map() map[string]string {
+	return Default.PrefixMap(p)
+}
+
+// CheckKeys reports the variables with the prefix p whose names, with the prefix removed, are not one of known.
+// See Env.CheckPrefix for details.
+func (p Prefix) CheckKeys(known ...string) error {
+	return Default.CheckPrefix(p, known...)
+}
+
+// Set sets the value named by key.
+func (p Prefix) Set(key, value string) error {
+	return Set(p.format(key), value)
+}
+
+// Unset removes the value named by key.
+func (p Prefix) Unset(key string) error {
+	return Unset(p.format(key))
+}
+
+// Clear removes every variable with the prefix p from Default.
+func (p Prefix) Clear() error {
+	return Default.Clear(p)
+}
+
+// SetString sets the value named by key to a string.
+// It is functionally the same as Set.
+func (p Prefix) SetString(key string, v string) error {
+	return SetString(p.format(key), v)
+}
+
+// SetInt sets the value named by key to an int, formatted so that GetInt retrieves v.
+func (p Prefix) SetInt(key string, v int) error {
+	return SetInt(p.format(key), v)
+}
+
+// SetInt8 sets the value named by key to an int8, formatted so that GetInt8 retrieves v.
+func (p Prefix) SetInt8(key string, v int8) error {
+	return SetInt8(p.format(key), v)
+}
+
+// SetInt16 sets the value named by key to an int16, formatted so that GetInt16 retrieves v.
+func (p Prefix) SetInt16(key string, v int16) error {
+	return SetInt16(p.format(key), v)
+}
+
+// SetInt32 sets the value named by key to an int32, formatted so that GetInt32 retrieves v.
+func (p Prefix) SetInt32(key string, v int32) error {
+	return SetInt32(p.format(key), v)
+}
+
+// SetInt64 sets the value named by key to an int64, formatted so that GetInt64 retrieves v.
+func (p Prefix) SetInt64(key string, v int64) error {
+	return SetInt64(p.format(key), v)
+}
+
+// SetUInt sets the value named by key to a uint, formatted so that GetUInt retrieves v.
+func (p Prefix) SetUInt(key string, v uint) error {
+	return SetUInt(p.format(key), v)
+}
+
+// SetUInt8 sets the value named by key to a uint8, formatted so that GetUInt8 retrieves v.
+func (p Prefix) SetUInt8(key string, v uint8) error {
+	return SetUInt8(p.format(key), v)
+}
+
+// SetUInt16 sets the value named by key to a uint16, formatted so that GetUInt16 retrieves v.
+func (p Prefix) SetUInt16(key string, v uint16) error {
+	return SetUInt16(p.format(key), v)
+}
+
+// SetUInt32 sets the value named by key to a uint32, formatted so that GetUInt32 retrieves v.
+func (p Prefix) SetUInt32(key string, v uint32) error {
+	return SetUInt32(p.format(key), v)
+}
+
+// SetUInt64 sets the value named by key to a uint64, formatted so that GetUInt64 retrieves v.
+func (p Prefix) SetUInt64(key string, v uint64) error {
+	return SetUInt64(p.format(key), v)
+}
+
+// SetFloat32 sets the value named by key to a float32, formatted so that GetFloat32 retrieves v.
+func (p Prefix) SetFloat32(key string, v float32) error {
+	return SetFloat32(p.format(key), v)
+}
+
+// SetFloat64 sets the value named by key to a float64, formatted so that GetFloat64 retrieves v.
+func (p Prefix) SetFloat64(key string, v float64) error {
+	return SetFloat64(p.format(key), v)
+}
+
+// SetBool sets the value named by key to a bool, formatted so that GetBool retrieves v.
+func (p Prefix) SetBool(key string, v bool) error {
+	return SetBool(p.format(key), v)
+}
+
+// SetDuration sets the value named by key to a time.Duration, formatted so that GetDuration retrieves v.
+func (p Prefix) SetDuration(key string, v time.Duration) error {
+	return SetDuration(p.format(key), v)
+}
+
+// Sub returns the Prefix nested under p named by name, e.g. Prefix("APP").Sub("DB") retrieves APP_DB_HOST for HOST.
+func (p Prefix) Sub(name string) Prefix {
+	return Prefix(p.format(name))
+}
+
+// Key returns the name of the variable retrieved for key, e.g. APP_PORT for Prefix("APP").Key("PORT").
+func (p Prefix) Key(key string) string {
+	return p.format(key)
+}
+
+// Marshal returns the values of the tagged fields of the struct pointed to by v as KEY=value pairs sorted by key, with
+// the keys of the fields prefixed by p. See Env.MarshalMap for details.
+func (p Prefix) Marshal(v interface{}) ([]string, error) {
+	m, err := p.MarshalMap(v)
+	if err != nil {
+		return nil, err
+	}
+	return m.Environ(), nil
+}
+
+// MarshalMap returns the values of the tagged fields of the struct pointed to by v, keyed by the names of the
+// variables prefixed by p. See Env.MarshalMap for details.
+func (p Prefix) MarshalMap(v interface{}) (Map, error) {
+	return Default.marshal(v, p, false)
+}
+
+// MarshalMasked is like MarshalMap, but replaces the values of secret fields with SecretMask.
+func (p Prefix) MarshalMasked(v interface{}) (Map, error) {
+	return Default.marshal(v, p, true)
+}
+
 func (p Prefix) format(key string) string {
 	return string(p) + "_" + key
 }
+
+// key is like format, but returns key unchanged for an empty Prefix.
+func (p Prefix) key(key string) string {
+	if p == "" {
+		return key
+	}
+	return p.format(key)
+}
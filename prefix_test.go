@@ -1,8 +1,8 @@
 package env
 
 import (
+	"errors"
 	"fmt"
-	"os"
 	"reflect"
 	"runtime"
 	"testing"
@@ -215,7 +215,7 @@ func TestPrefix_Get(t *testing.T) {
 	for i, test := range tests {
 		fnName := runtime.FuncForPC(reflect.ValueOf(test.fn).Pointer()).Name()
 		t.Run(fmt.Sprintf("%d %v", i, fnName), func(t *testing.T) {
-			_ = os.Setenv("FOO_FOO", test.value)
+			t.Setenv("FOO_FOO", test.value)
 
 			args := []reflect.Value{reflect.ValueOf("FOO")}
 
@@ -445,7 +445,7 @@ func TestPrefix_GetD(t *testing.T) {
 	for i, test := range tests {
 		fnName := runtime.FuncForPC(reflect.ValueOf(test.fn).Pointer()).Name()
 		t.Run(fmt.Sprintf("%d %v", i, fnName), func(t *testing.T) {
-			_ = os.Setenv("FOO_FOO", test.value)
+			t.Setenv("FOO_FOO", test.value)
 
 			args := []reflect.Value{reflect.ValueOf("FOO"), reflect.ValueOf(test.def)}
 
@@ -455,3 +455,34 @@ func TestPrefix_GetD(t *testing.T) {
 		})
 	}
 }
+
+func TestPrefix_GetE(t *testing.T) {
+	prefix := Prefix("FOO")
+
+	t.Setenv("FOO_FOO", "80a")
+	_, err := prefix.GetIntE("FOO")
+
+	var parseErr *ParseError
+	require.True(t, errors.As(err, &parseErr))
+	require.Equal(t, "FOO_FOO", parseErr.Key)
+	require.Equal(t, "80a", parseErr.Value)
+
+	unsetenv(t, "FOO_FOO")
+	_, err = prefix.GetDurationE("FOO")
+	require.True(t, errors.Is(err, ErrNotSet))
+
+	t.Setenv("FOO_FOO", "5s")
+	d, err := prefix.GetDurationE("FOO")
+	require.NoError(t, err)
+	require.Equal(t, 5*time.Second, d)
+}
+
+func TestPrefix_GetD_ExplicitZero(t *testing.T) {
+	prefix := Prefix("FOO")
+
+	t.Setenv("FOO_RETRIES", "0")
+	require.Equal(t, 0, prefix.GetIntD("RETRIES", 3))
+
+	unsetenv(t, "FOO_RETRIES")
+	require.Equal(t, 3, prefix.GetIntD("RETRIES", 3))
+}
@@ -0,0 +1,463 @@
+package env
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+)
+
+// Provenance records a lookup of a variable and where its value came from.
+type Provenance struct {
+	Key          string // the name of the variable
+	Caller       string // the file:line of the code that looked up the variable, outside of this package
+	Present      bool   // whether the variable was present
+	Value        string // the raw value, or SecretMask if Secret
+	Layer        string // the name of the Layer the value came from, if the Source is Layers
+	File         string // the file the value came from, e.g. a .env file or the file named by KEY_FILE
+	Line         int    // the 1-based line of File the value is defined on, if known
+	Default      bool   // whether the default passed to the getter was returned instead of the value
+	DefaultValue string // the default passed to the getter, if any, or SecretMask if Secret
+	Err          error  // the error retrieving, parsing or validating the value, if any
+	Secret       bool   // whether the value is a secret, in which case Value and DefaultValue are masked
+}
+
+// Outcomes of a lookup, as returned by Provenance.Outcome.
+const (
+	OutcomeSet     = "set"     // the value was present and valid
+	OutcomeUnset   = "unset"   // the value was not present, and no default was returned
+	OutcomeDefault = "default" // the value was not present, and the default was returned
+	OutcomeInvalid = "invalid" // the value could not be retrieved, parsed or validated
+)
+
+// Outcome returns the outcome of the lookup, one of OutcomeSet, OutcomeUnset, OutcomeDefault or OutcomeInvalid.
+func (p Provenance) Outcome() string {
+	switch {
+	case p.Err != nil:
+		return OutcomeInvalid
+	case p.Default:
+		return OutcomeDefault
+	case p.Present:
+		return OutcomeSet
+	}
+	return OutcomeUnset
+}
+
+// MarshalJSON encodes p as a JSON object, with Err as a string and the Outcome.
+func (p Provenance) MarshalJSON() ([]byte, error) {
+	var errMsg string
+	if p.Err != nil {
+		errMsg = p.Err.Error()
+	}
+	return json.Marshal(struct {
+		Key          string `json:"key"`
+		Caller       string `json:"caller,omitempty"`
+		Outcome      string `json:"outcome"`
+		Present      bool   `json:"present"`
+		Value        string `json:"value,omitempty"`
+		Layer        string `json:"layer,omitempty"`
+		File         string `json:"file,omitempty"`
+		Line         int    `json:"line,omitempty"`
+		Default      bool   `json:"default,omitempty"`
+		DefaultValue string `json:"default_value,omitempty"`
+		Err          string `json:"error,omitempty"`
+		Secret       bool   `json:"secret,omitempty"`
+	}{p.Key, p.Caller, p.Outcome(), p.Present, p.Value, p.Layer, p.File, p.Line, p.Default, p.DefaultValue, errMsg,
+		p.Secret})
+}
+
+// String describes p in a single line, e.g.
+// APP_PORT="80x" from layer local .env.local:4: env: APP_PORT: parsing "80x" as int: invalid syntax (default "8080" used)
+func (p Provenance) String() string {
+	var sb strings.Builder
+	sb.WriteString(p.Key)
+	if p.Present {
+		fmt.Fprintf(&sb, "=%q", p.Value)
+	} else {
+		sb.WriteString(" not set")
+	}
+
+	var from []string
+	if p.Layer != "" {
+		from = append(from, "layer "+p.Layer)
+	}
+	if p.File != "" && p.Line > 0 {
+		from = append(from, fmt.Sprintf("%s:%d", p.File, p.Line))
+	} else if p.File != "" {
+		from = append(from, p.File)
+	}
+	if len(from) > 0 {
+		sb.WriteString(" from " + strings.Join(from, " "))
+	}
+
+	if p.Err != nil {
+		sb.WriteString(": " + p.Err.Error())
+	}
+	if p.Default {
+		fmt.Fprintf(&sb, " (default %q used)", p.DefaultValue)
+	}
+	if p.Caller != "" {
+		sb.WriteString(" at " + p.Caller)
+	}
+	return sb.String()
+}
+
+// Tracker records the provenance of the lookups of an Env, so the origin of a value can be explained, and the variables
+// a program reads can be audited. Set it as the Tracker of an Env to enable tracking. The zero value is ready to use.
+//
+// Explain, Inventory, Unread and WriteReport only keep state for each variable, but Lookups retains every lookup
+// unless MaxLookups is set, so long-running programs should set it.
+//
+// The default and error of a lookup are attributed to the latest lookup of the same variable, so concurrent lookups of
+// one variable may be attributed to each other.
+type Tracker struct {
+	// MaxLookups limits the number of lookups retained for Lookups and WriteJSON, discarding the oldest. If zero, every
+	// lookup is retained, and if negative, none are.
+	MaxLookups int
+
+	mu      sync.Mutex
+	lookups []*Provenance
+	latest  map[string]*Provenance
+	usages  map[string]*Usage
+	order   []string        // the keys looked up, in the order they were first looked up
+	read    map[string]bool // the keys read while resolving other variables, e.g. by expansion or as KEY_FILE
+}
+
+// NewTracker returns an empty Tracker.
+func NewTracker() *Tracker {
+	return &Tracker{}
+}
+
+// Explain returns the provenance of the latest lookup of the variable named by key, and reports whether it was
+// looked up.
+func (t *Tracker) Explain(key string) (Provenance, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	p, ok := t.latest[key]
+	if !ok {
+		return Provenance{}, false
+	}
+	return *p, true
+}
+
+// Lookups returns the provenance of the retained lookups in the order they were performed. See MaxLookups.
+func (t *Tracker) Lookups() []Provenance {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var lookups []Provenance
+	for _, p := range t.lookups {
+		lookups = append(lookups, *p)
+	}
+	return lookups
+}
+
+// Reset discards the recorded lookups.
+func (t *Tracker) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.lookups, t.latest, t.usages, t.order, t.read = nil, nil, nil, nil, nil
+}
+
+// WriteReport writes a table describing the latest lookup of each variable to w, in the order they were first looked
+// up, suitable for support bundles.
+func (t *Tracker) WriteReport(w io.Writer) error {
+	t.mu.Lock()
+	latest := make([]Provenance, len(t.order))
+	for i, key := range t.order {
+		latest[i] = *t.latest[key]
+	}
+	t.mu.Unlock()
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	ew := &errWriter{w: tw}
+	ew.printf("KEY\tVALUE\tSOURCE\tNOTE\n")
+	for _, p := range latest {
+		value := "<not set>"
+		if p.Present {
+			value = fmt.Sprintf("%q", p.Value)
+		}
+
+		source := p.Layer
+		if p.File != "" {
+			loc := p.File
+			if p.Line > 0 {
+				loc = fmt.Sprintf("%s:%d", p.File, p.Line)
+			}
+			source = strings.TrimSpace(source + " " + loc)
+		}
+
+		var notes []string
+		if p.Err != nil {
+			notes = append(notes, strings.TrimPrefix(p.Err.Error(), "env: "))
+		}
+		if p.Default {
+			notes = append(notes, fmt.Sprintf("default %q used", p.DefaultValue))
+		}
+		ew.printf("%s\t%s\t%s\t%s\n", p.Key, value, source, strings.Join(notes, "; "))
+	}
+	if ew.err != nil {
+		return ew.err
+	}
+	return tw.Flush()
+}
+
+// Usage summarizes the lookups of a variable recorded by a Tracker.
+type Usage struct {
+	Key      string         `json:"key"`
+	Count    int            `json:"count"`              // the number of lookups
+	Callers  []string       `json:"callers"`            // the distinct callers, in the order they first looked it up
+	Defaults []string       `json:"defaults,omitempty"` // the distinct defaults supplied, in the order they were first supplied
+	Outcomes map[string]int `json:"outcomes"`           // the number of lookups of each outcome
+}
+
+// Inventory summarizes the lookups of each variable, in key order. Unlike Lookups, it covers every lookup.
+func (t *Tracker) Inventory() []Usage {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	usages := make([]Usage, 0, len(t.usages))
+	for _, u := range t.usages {
+		c := *u
+		c.Callers = append([]string{}, u.Callers...)
+		c.Defaults = append([]string(nil), u.Defaults...)
+		c.Outcomes = make(map[string]int, len(u.Outcomes))
+		for o, n := range u.Outcomes {
+			c.Outcomes[o] = n
+		}
+		usages = append(usages, c)
+	}
+	sort.Slice(usages, func(i, j int) bool { return usages[i].Key < usages[j].Key })
+	return usages
+}
+
+// Unread returns the keys present in src that were never read, in sorted order, e.g. variables set in a deployment
+// manifest that the program does not use. A variable is read if it was looked up, referenced by an expanded value, or
+// named the file of a variable as KEY_FILE. It returns nil if src does not implement Enumerator.
+func (t *Tracker) Unread(src Source) []string {
+	keys, _ := Keys(src)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var unread []string
+	for _, k := range keys {
+		if _, ok := t.latest[k]; !ok && !t.read[k] {
+			unread = append(unread, k)
+		}
+	}
+	return unread
+}
+
+// WriteJSON writes the recorded lookups and their Inventory to w as a JSON object with the fields "lookups" and
+// "inventory".
+func (t *Tracker) WriteJSON(w io.Writer) error {
+	lookups, inventory := t.Lookups(), t.Inventory()
+	if lookups == nil {
+		lookups = []Provenance{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(struct {
+		Lookups   []Provenance `json:"lookups"`
+		Inventory []Usage      `json:"inventory"`
+	}{lookups, inventory})
+}
+
+func contains(s []string, v string) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
+
+// record records a lookup performed by e.
+func (t *Tracker) record(e *Env, key, v string, ok, fromFile bool, err error) {
+	p := Provenance{Key: key, Caller: caller(), Present: ok, Value: v, Err: err}
+
+	src := e.source()
+	located := key
+	if fromFile {
+		located = key + FileSuffix
+		p.File, _ = src.Lookup(located)
+	}
+	if l, isLayers := src.(Layers); isLayers {
+		p.Layer, _ = l.Which(located)
+	}
+	if loc, isLocator := src.(Locator); isLocator && !fromFile {
+		p.File, p.Line, _ = loc.Locate(key)
+	}
+	if d, declared := DefaultRegistry.Lookup(key); declared && d.Secret {
+		p.Secret = true
+	}
+	p.mask()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.latest == nil {
+		t.latest, t.usages = map[string]*Provenance{}, map[string]*Usage{}
+	}
+	u, ok := t.usages[key]
+	if !ok {
+		u = &Usage{Key: key, Callers: []string{}, Outcomes: map[string]int{}}
+		t.usages[key] = u
+		t.order = append(t.order, key)
+	}
+	u.Count++
+	u.Outcomes[p.Outcome()]++
+	if p.Caller != "" && !contains(u.Callers, p.Caller) {
+		u.Callers = append(u.Callers, p.Caller)
+	}
+
+	t.latest[key] = &p
+	if t.MaxLookups >= 0 {
+		t.lookups = append(t.lookups, &p)
+	}
+	if t.MaxLookups > 0 && len(t.lookups) > t.MaxLookups {
+		t.lookups[0] = nil
+		t.lookups = t.lookups[1:]
+	}
+}
+
+// update applies fn to the latest lookup of the variable named by key, if any.
+func (t *Tracker) update(key string, fn func(p *Provenance)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	p, ok := t.latest[key]
+	if !ok {
+		return
+	}
+	u := t.usages[key]
+	if u.Outcomes[p.Outcome()]--; u.Outcomes[p.Outcome()] == 0 {
+		delete(u.Outcomes, p.Outcome())
+	}
+	fn(p)
+	p.mask()
+	u.Outcomes[p.Outcome()]++
+	if p.DefaultValue != "" && !contains(u.Defaults, p.DefaultValue) {
+		u.Defaults = append(u.Defaults, p.DefaultValue)
+	}
+}
+
+// markRead records key as read while resolving another variable.
+func (t *Tracker) markRead(key string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.read == nil {
+		t.read = map[string]bool{}
+	}
+	t.read[key] = true
+}
+
+func (p *Provenance) mask() {
+	if !p.Secret {
+		return
+	}
+	if p.Value != "" {
+		p.Value = SecretMask
+	}
+	if p.DefaultValue != "" {
+		p.DefaultValue = SecretMask
+	}
+}
+
+// Explain returns the provenance of the latest lookup of the variable named by key by e, and reports whether it was
+// looked up. It reports false if e has no Tracker.
+func (e *Env) Explain(key string) (Provenance, bool) {
+	if e.Tracker == nil {
+		return Provenance{}, false
+	}
+	return e.Tracker.Explain(key)
+}
+
+// Explain returns the provenance of the latest lookup of the variable named by key by Default.
+func Explain(key string) (Provenance, bool) {
+	return Default.Explain(key)
+}
+
+// failed records err as the error of the latest lookup of key, and returns it.
+func (e *Env) failed(key string, err error) error {
+	if e.Tracker != nil {
+		e.Tracker.update(key, func(p *Provenance) { p.Err = err })
+	}
+	return err
+}
+
+// parseError is like newParseError, but records the error as the error of the latest lookup of key.
+func (e *Env) parseError(key, value, typ string, err error) error {
+	return e.failed(key, newParseError(key, value, typ, err))
+}
+
+// elementError is like newElementError, but records the error as the error of the latest lookup of key.
+func (e *Env) elementError(key, value, typ string, i int, elem string, err error) error {
+	return e.failed(key, newElementError(key, value, typ, i, elem, err))
+}
+
+// secret records the latest lookup of key as the lookup of a secret.
+func (e *Env) secret(key string) {
+	if e.Tracker != nil {
+		e.Tracker.update(key, func(p *Provenance) { p.Secret = true })
+	}
+}
+
+// supplyDefault records def as the default supplied to the latest lookup of key.
+func supplyDefault[T any](e *Env, key string, def T) {
+	if e.Tracker != nil {
+		e.Tracker.update(key, func(p *Provenance) {
+			p.Secret = p.Secret || isSecret(def)
+			p.DefaultValue = formatDefault(reflect.ValueOf(&def).Elem())
+		})
+	}
+}
+
+// useDefault records that def is returned instead of the value of key, and returns it.
+func useDefault[T any](e *Env, key string, def T) T {
+	if e.Tracker != nil {
+		e.Tracker.update(key, func(p *Provenance) {
+			p.Default = true
+			p.Secret = p.Secret || isSecret(def)
+			p.DefaultValue = formatDefault(reflect.ValueOf(&def).Elem())
+		})
+	}
+	return def
+}
+
+// isSecret reports whether v is a Secret, whose lookups are recorded as secrets.
+func isSecret(v interface{}) bool {
+	_, ok := v.(Secret)
+	return ok
+}
+
+// pkgDir is the directory of the source files of this package, whose frames caller skips.
+var pkgDir = func() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Dir(file)
+}()
+
+// caller returns the file:line of the first frame on the stack outside of this package, excluding its tests.
+func caller() string {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	for {
+		f, more := frames.Next()
+		if f.File != "" && (filepath.Dir(f.File) != pkgDir || strings.HasSuffix(f.File, "_test.go")) {
+			return fmt.Sprintf("%s:%d", f.File, f.Line)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
@@ -0,0 +1,277 @@
+package env
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTracker(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, ".env.local")
+	require.NoError(t, os.WriteFile(filename, []byte("# local\nAPP_HOST=localhost\n\nAPP_PORT=80x\n"), 0o600))
+	local, err := OpenDotenv(filename)
+	require.NoError(t, err)
+
+	tr := NewTracker()
+	e := New(Layers{
+		{Name: "flags", Source: Map{"APP_DEBUG": "true"}},
+		{Name: "local", Source: local},
+	})
+	e.Tracker = tr
+
+	require.Equal(t, true, e.GetBool("APP_DEBUG"))
+	require.Equal(t, "localhost", e.GetD("APP_HOST", "0.0.0.0"))
+	require.Equal(t, 8080, e.GetIntD("APP_PORT", 8080))
+	require.Equal(t, 3, e.GetIntD("APP_RETRIES", 3))
+
+	p := explain(t, e, "APP_DEBUG")
+	require.Equal(t, Provenance{Key: "APP_DEBUG", Present: true, Value: "true", Layer: "flags"}, p)
+
+	p = explain(t, e, "APP_HOST")
+	require.Equal(t, Provenance{Key: "APP_HOST", Present: true, Value: "localhost", Layer: "local", File: filename, Line: 2,
+		DefaultValue: "0.0.0.0"}, p)
+
+	p = explain(t, e, "APP_PORT")
+	require.True(t, p.Default)
+	require.Equal(t, "8080", p.DefaultValue)
+	var perr *ParseError
+	require.True(t, errors.As(p.Err, &perr))
+	require.Equal(t, 4, p.Line)
+	require.Equal(t, `APP_PORT="80x" from layer local `+filename+`:4: `+perr.Error()+` (default "8080" used)`, p.String())
+
+	p = explain(t, e, "APP_RETRIES")
+	require.Equal(t, Provenance{Key: "APP_RETRIES", Default: true, DefaultValue: "3"}, p)
+	require.Equal(t, `APP_RETRIES not set (default "3" used)`, p.String())
+
+	_, ok := e.Explain("MISSING")
+	require.False(t, ok)
+	require.Len(t, tr.Lookups(), 4)
+
+	var buf bytes.Buffer
+	require.NoError(t, tr.WriteReport(&buf))
+	require.Contains(t, buf.String(), "APP_RETRIES")
+	require.Contains(t, buf.String(), `default "3" used`)
+
+	tr.Reset()
+	require.Empty(t, tr.Lookups())
+}
+
+func TestTracker_Secret(t *testing.T) {
+	e := New(Map{"TOKEN": "hunter2"})
+	e.Tracker = &Tracker{}
+
+	require.Equal(t, Secret("hunter2"), e.GetSecret("TOKEN"))
+	p, _ := e.Explain("TOKEN")
+	require.True(t, p.Secret)
+	require.Equal(t, SecretMask, p.Value)
+
+	e.GetSecretD("MISSING", "fallback")
+	p, _ = e.Explain("MISSING")
+	require.Equal(t, SecretMask, p.DefaultValue)
+
+	GetOrIn(e, "GENERIC", Secret("generic-fallback"))
+	p, _ = e.Explain("GENERIC")
+	require.True(t, p.Secret)
+	require.Equal(t, SecretMask, p.DefaultValue)
+
+	for _, u := range e.Tracker.Inventory() {
+		for _, d := range u.Defaults {
+			require.Equal(t, SecretMask, d, u.Key)
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, e.Tracker.WriteJSON(&buf))
+	require.NotContains(t, buf.String(), "fallback")
+	require.NotContains(t, buf.String(), "hunter2")
+}
+
+func TestTracker_Generic(t *testing.T) {
+	e := New(Map{"PORT": "0", "FILE_KEY_FILE": "/does/not/exist"})
+	e.Tracker = &Tracker{}
+	e.Files = true
+
+	require.Equal(t, uint16(80), GetOrIn(e, "PORT", uint16(80), Min[uint16](1)))
+	p, _ := e.Explain("PORT")
+	require.True(t, p.Default)
+	var verr *ValidationError
+	require.True(t, errors.As(p.Err, &verr))
+
+	_, err := e.GetStringE("FILE_KEY")
+	require.Error(t, err)
+	p, _ = e.Explain("FILE_KEY")
+	require.Equal(t, err, p.Err)
+}
+
+func TestTracker_Elements(t *testing.T) {
+	e := New(Map{"PORTS": "80,x", "LIMITS": "a:1,b:x"})
+	e.Tracker = &Tracker{}
+
+	_, err := e.GetIntsE("PORTS")
+	require.Error(t, err)
+	p, _ := e.Explain("PORTS")
+	require.Equal(t, err, p.Err)
+	require.Equal(t, OutcomeInvalid, p.Outcome())
+
+	require.Equal(t, []int{443}, e.GetIntsD("PORTS", []int{443}))
+	p, _ = e.Explain("PORTS")
+	require.True(t, p.Default)
+	require.Equal(t, OutcomeInvalid, p.Outcome())
+
+	_, err = e.GetIntMapE("LIMITS")
+	var elemErr *ElementError
+	require.True(t, errors.As(err, &elemErr))
+	p, _ = e.Explain("LIMITS")
+	require.Equal(t, err, p.Err)
+	require.Equal(t, OutcomeInvalid, p.Outcome())
+
+	_, err = e.GetDurationMapE("LIMITS")
+	require.Error(t, err)
+	p, _ = e.Explain("LIMITS")
+	require.Equal(t, err, p.Err)
+}
+
+func TestTracker_File(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "password")
+	require.NoError(t, os.WriteFile(filename, []byte("s3cr3t\n"), 0o600))
+
+	e := New(Layers{{Name: "os", Source: Map{"PASSWORD_FILE": filename}}})
+	e.Files = true
+	e.Tracker = &Tracker{}
+
+	require.Equal(t, "s3cr3t", e.Get("PASSWORD"))
+	p := explain(t, e, "PASSWORD")
+	require.Equal(t, Provenance{Key: "PASSWORD", Present: true, Value: "s3cr3t", Layer: "os", File: filename}, p)
+}
+
+// explain returns the provenance of key with its Caller, which must be in this file, cleared.
+func explain(t *testing.T, e *Env, key string) Provenance {
+	t.Helper()
+
+	p, ok := e.Explain(key)
+	require.True(t, ok)
+	require.Regexp(t, `provenance_test\.go:\d+$`, p.Caller)
+	p.Caller = ""
+	return p
+}
+
+func TestExplain_NoTracker(t *testing.T) {
+	_, ok := New(Map{}).Explain("FOO")
+	require.False(t, ok)
+}
+
+func TestTracker_Inventory(t *testing.T) {
+	prev := Default
+	defer func() { Default = prev }()
+	src := Map{"APP_PORT": "8080", "APP_DEBUG": "x", "APP_UNUSED": "1"}
+	Default = New(src)
+	Default.Tracker = &Tracker{}
+
+	for i := 0; i < 2; i++ {
+		Prefix("APP").GetInt("PORT")
+	}
+	Prefix("APP").GetBoolD("DEBUG", false)
+	GetDurationD("TIMEOUT", time.Second)
+	GetDurationD("TIMEOUT", time.Minute)
+
+	inv := Default.Tracker.Inventory()
+	require.Len(t, inv, 3)
+
+	require.Equal(t, "APP_DEBUG", inv[0].Key)
+	require.Equal(t, map[string]int{OutcomeInvalid: 1}, inv[0].Outcomes)
+	require.Equal(t, []string{"false"}, inv[0].Defaults)
+
+	require.Equal(t, "APP_PORT", inv[1].Key)
+	require.Equal(t, 2, inv[1].Count)
+	require.Len(t, inv[1].Callers, 1)
+	require.Regexp(t, `provenance_test\.go:\d+$`, inv[1].Callers[0])
+	require.Equal(t, map[string]int{OutcomeSet: 2}, inv[1].Outcomes)
+
+	require.Equal(t, "TIMEOUT", inv[2].Key)
+	require.Len(t, inv[2].Callers, 2)
+	require.Equal(t, []string{"1s", "1m0s"}, inv[2].Defaults)
+	require.Equal(t, map[string]int{OutcomeDefault: 2}, inv[2].Outcomes)
+
+	require.Equal(t, []string{"APP_UNUSED"}, Default.Tracker.Unread(src))
+
+	var buf bytes.Buffer
+	require.NoError(t, Default.Tracker.WriteJSON(&buf))
+	var out struct {
+		Lookups []struct {
+			Key     string `json:"key"`
+			Caller  string `json:"caller"`
+			Outcome string `json:"outcome"`
+			Error   string `json:"error"`
+		} `json:"lookups"`
+		Inventory []Usage `json:"inventory"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &out))
+	require.Len(t, out.Lookups, 5)
+	require.Equal(t, "APP_DEBUG", out.Lookups[2].Key)
+	require.Equal(t, OutcomeInvalid, out.Lookups[2].Outcome)
+	require.NotEmpty(t, out.Lookups[2].Error)
+	require.Equal(t, inv, out.Inventory)
+}
+
+func TestTracker_WriteJSON_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, (&Tracker{}).WriteJSON(&buf))
+	require.JSONEq(t, `{"lookups": [], "inventory": []}`, buf.String())
+}
+
+func TestTracker_Unread_Indirect(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "password")
+	require.NoError(t, os.WriteFile(filename, []byte("s3cr3t\n"), 0o600))
+	src := Map{
+		"DB_HOST":          "db",
+		"DB_PASSWORD_FILE": filename,
+		"DB_URL":           "postgres://app:${DB_PASSWORD}@${DB_HOST}",
+		"UNUSED":           "1",
+	}
+
+	e := New(src)
+	e.Expand, e.Files = true, true
+	e.Tracker = NewTracker()
+
+	require.Equal(t, "postgres://app:s3cr3t@db", e.Get("DB_URL"))
+	require.Equal(t, []string{"UNUSED"}, e.Tracker.Unread(src))
+
+	e.Tracker.Reset()
+	require.Equal(t, []string{"DB_HOST", "DB_PASSWORD_FILE", "DB_URL", "UNUSED"}, e.Tracker.Unread(src))
+}
+
+func TestTracker_MaxLookups(t *testing.T) {
+	e := New(Map{"A": "1", "B": "x"})
+	e.Tracker = &Tracker{MaxLookups: 2}
+
+	e.GetInt("A")
+	e.GetIntD("B", 2)
+	e.GetInt("A")
+
+	lookups := e.Tracker.Lookups()
+	require.Len(t, lookups, 2)
+	require.Equal(t, "B", lookups[0].Key)
+	require.Equal(t, "A", lookups[1].Key)
+
+	inv := e.Tracker.Inventory()
+	require.Equal(t, 2, inv[0].Count)
+	require.Equal(t, map[string]int{OutcomeInvalid: 1}, inv[1].Outcomes)
+	require.Equal(t, []string{"2"}, inv[1].Defaults)
+
+	p, ok := e.Tracker.Explain("B")
+	require.True(t, ok)
+	require.True(t, p.Default)
+
+	e.Tracker = &Tracker{MaxLookups: -1}
+	e.GetInt("A")
+	require.Empty(t, e.Tracker.Lookups())
+	require.Len(t, e.Tracker.Inventory(), 1)
+	_, ok = e.Tracker.Explain("A")
+	require.True(t, ok)
+}
@@ -0,0 +1,276 @@
+package env
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+	"text/tabwriter"
+)
+
+// Var describes a variable read by a program, so its configuration can be documented.
+type Var struct {
+	Name        string // the full name of the variable, e.g. APP_PORT
+	Type        string // the type the value is parsed as, e.g. "int"
+	Default     string // the value used when the variable is not present
+	Description string // a description of what the variable configures
+	Required    bool   // whether the variable must be present
+	Secret      bool   // whether the value is sensitive, in which case Default is never rendered
+}
+
+// Registry holds the declared variables of a program, and renders their documentation.
+// The zero value is an empty Registry ready to use.
+type Registry struct {
+	mu    sync.Mutex
+	vars  []Var
+	index map[string]int
+}
+
+// DefaultRegistry is the Registry used by Declare, DeclareStruct and their Prefix equivalents.
+var DefaultRegistry = &Registry{}
+
+// Declare declares vars in DefaultRegistry.
+func Declare(vars ...Var) {
+	DefaultRegistry.Declare(vars...)
+}
+
+// Declare declares vars in r. A variable with the same name as a previously declared one replaces it.
+func (r *Registry) Declare(vars ...Var) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.index == nil {
+		r.index = map[string]int{}
+	}
+	for _, v := range vars {
+		if i, ok := r.index[v.Name]; ok {
+			r.vars[i] = v
+			continue
+		}
+		r.index[v.Name] = len(r.vars)
+		r.vars = append(r.vars, v)
+	}
+}
+
+// DeclarePrefix declares vars in r, with the name of each prefixed by p.
+func (r *Registry) DeclarePrefix(p Prefix, vars ...Var) {
+	prefixed := make([]Var, len(vars))
+	for i, v := range vars {
+		v.Name = p.format(v.Name)
+		prefixed[i] = v
+	}
+	r.Declare(prefixed...)
+}
+
+// DeclareStruct declares a variable in DefaultRegistry for each tagged field of the struct pointed to by v.
+// See Registry.DeclareStruct for details.
+func DeclareStruct(v interface{}) error {
+	return DefaultRegistry.DeclareStruct("", v)
+}
+
+// DeclareStruct declares a variable in r for each tagged field of the struct pointed to by v, with the keys of the
+// fields resolved as by Unmarshal and prefixed by p, if not empty.
+//
+// The Type of each variable is the type of the field, and its Default is the current value of the field, if not zero.
+// The Description is read from a `desc` tag, Required from the required rule of a `validate` tag, and Secret from the
+// secret option of the `env` tag or a field of type Secret.
+func (r *Registry) DeclareStruct(p Prefix, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return errors.New("env: DeclareStruct requires a non-nil pointer to a struct")
+	}
+
+	var vars []Var
+	err := walkStruct(rv.Elem(), p, func(f structField) error {
+		var def string
+		if !f.value.IsZero() {
+			def = formatDefault(f.value)
+		}
+		vars = append(vars, Var{
+			Name:        f.key,
+			Type:        f.value.Type().String(),
+			Default:     def,
+			Description: f.field.Tag.Get("desc"),
+			Required:    hasRule(f.field.Tag.Get("validate"), "required"),
+			Secret:      f.secret,
+		})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	r.Declare(vars...)
+	return nil
+}
+
+// Lookup returns the variable declared with name and reports whether it was declared.
+func (r *Registry) Lookup(name string) (Var, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i, ok := r.index[name]
+	if !ok {
+		return Var{}, false
+	}
+	return r.vars[i], true
+}
+
+// Vars returns the declared variables in the order they were first declared.
+func (r *Registry) Vars() []Var {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]Var(nil), r.vars...)
+}
+
+// CheckPrefix reports the variables of Default with the prefix p that are not declared in r.
+// See Env.CheckPrefix for details.
+func (r *Registry) CheckPrefix(p Prefix) error {
+	prefix := p.format("")
+	var known []string
+	for _, v := range r.Vars() {
+		if strings.HasPrefix(v.Name, prefix) {
+			known = append(known, v.Name[len(prefix):])
+		}
+	}
+	return Default.CheckPrefix(p, known...)
+}
+
+// WriteMarkdown writes a Markdown table documenting the declared variables to w.
+func (r *Registry) WriteMarkdown(w io.Writer) error {
+	ew := &errWriter{w: w}
+	ew.printf("| Name | Type | Default | Required | Description |\n")
+	ew.printf("| ---- | ---- | ------- | -------- | ----------- |\n")
+	for _, v := range r.Vars() {
+		def := ""
+		if v.Default != "" {
+			def = "`" + strings.ReplaceAll(v.displayDefault(), "`", "'") + "`"
+		}
+		required := ""
+		if v.Required {
+			required = "yes"
+		}
+		ew.printf("| `%s` | %s | %s | %s | %s |\n", v.Name, markdownCell(v.Type), markdownCell(def), required,
+			markdownCell(v.Description))
+	}
+	return ew.err
+}
+
+// WriteHelp writes plain text documenting the declared variables to w, suitable for the output of --help.
+func (r *Registry) WriteHelp(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	ew := &errWriter{w: tw}
+	ew.printf("Environment variables:\n")
+	for _, v := range r.Vars() {
+		var notes []string
+		if v.Required {
+			notes = append(notes, "required")
+		}
+		if v.Secret {
+			notes = append(notes, "secret")
+		}
+		if v.Default != "" {
+			notes = append(notes, "default: "+v.displayDefault())
+		}
+
+		desc := v.Description
+		if len(notes) > 0 {
+			desc = strings.TrimSpace(desc + " (" + strings.Join(notes, ", ") + ")")
+		}
+		ew.printf("  %s\t%s\t%s\n", v.Name, v.Type, desc)
+	}
+	if ew.err != nil {
+		return ew.err
+	}
+	return tw.Flush()
+}
+
+// WriteExample writes an example .env file declaring every variable to w.
+// Each variable is preceded by a comment with its description, and is set to its default unless it is secret.
+func (r *Registry) WriteExample(w io.Writer) error {
+	ew := &errWriter{w: w}
+	for i, v := range r.Vars() {
+		if i > 0 {
+			ew.printf("\n")
+		}
+
+		var notes []string
+		if v.Type != "" {
+			notes = append(notes, v.Type)
+		}
+		if v.Required {
+			notes = append(notes, "required")
+		}
+		if v.Secret {
+			notes = append(notes, "secret")
+		}
+		comment := v.Description
+		if len(notes) > 0 {
+			comment = strings.TrimSpace(comment + " (" + strings.Join(notes, ", ") + ")")
+		}
+		for _, line := range strings.Split(comment, "\n") {
+			if line != "" {
+				ew.printf("# %s\n", line)
+			}
+		}
+
+		def := ""
+		if !v.Secret {
+			def = quoteDotenv(v.Default)
+		}
+		ew.printf("%s=%s\n", v.Name, def)
+	}
+	return ew.err
+}
+
+func (v Var) displayDefault() string {
+	if v.Secret {
+		return "<secret>"
+	}
+	return v.Default
+}
+
+func markdownCell(s string) string {
+	return strings.NewReplacer("|", `\|`, "\n", "<br>").Replace(s)
+}
+
+// quoteDotenv quotes s, if necessary, so it is parsed unchanged by ParseDotenv.
+func quoteDotenv(s string) string {
+	if s == "" || !strings.ContainsAny(s, " \t\r\n#'\"\\$") {
+		return s
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "$", `\$`, "\n", `\n`, "\r", `\r`, "\t", `\t`).Replace(s) + `"`
+}
+
+// errWriter writes formatted output to w until the first error, which is retained in err.
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (ew *errWriter) printf(format string, args ...interface{}) {
+	if ew.err != nil {
+		return
+	}
+	_, ew.err = fmt.Fprintf(ew.w, format, args...)
+}
+
+// formatDefault formats the value of a field as the Default of a Var, as it would be set in the environment.
+func formatDefault(v reflect.Value) string {
+	if s, err := Default.format(v); err == nil {
+		return s
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// hasRule reports whether the `validate` tag lists the rule named name.
+func hasRule(tag, name string) bool {
+	for _, rule := range strings.Split(tag, ",") {
+		if strings.TrimSpace(rule) == name {
+			return true
+		}
+	}
+	return false
+}
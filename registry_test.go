@@ -0,0 +1,139 @@
+package env
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testRegistry() *Registry {
+	r := &Registry{}
+	r.Declare(
+		Var{Name: "PORT", Type: "int", Default: "8080", Description: "Port to listen on"},
+		Var{Name: "MODE", Type: "string", Description: "One of dev|prod", Required: true},
+	)
+	r.DeclarePrefix("DB",
+		Var{Name: "PASSWORD", Type: "string", Default: "hunter2", Description: "Database password", Secret: true},
+		Var{Name: "DSN", Type: "string", Default: `host=localhost user="app"`},
+	)
+	return r
+}
+
+func TestRegistry_Declare(t *testing.T) {
+	r := testRegistry()
+	r.Declare(Var{Name: "PORT", Type: "uint16"})
+
+	v, ok := r.Lookup("PORT")
+	require.True(t, ok)
+	require.Equal(t, Var{Name: "PORT", Type: "uint16"}, v)
+
+	_, ok = r.Lookup("DB_PASSWORD")
+	require.True(t, ok)
+
+	names := []string{}
+	for _, v := range r.Vars() {
+		names = append(names, v.Name)
+	}
+	require.Equal(t, []string{"PORT", "MODE", "DB_PASSWORD", "DB_DSN"}, names)
+}
+
+func TestRegistry_WriteMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, testRegistry().WriteMarkdown(&buf))
+	require.Equal(t, strings.Join([]string{
+		"| Name | Type | Default | Required | Description |",
+		"| ---- | ---- | ------- | -------- | ----------- |",
+		"| `PORT` | int | `8080` |  | Port to listen on |",
+		"| `MODE` | string |  | yes | One of dev\\|prod |",
+		"| `DB_PASSWORD` | string | `<secret>` |  | Database password |",
+		"| `DB_DSN` | string | `host=localhost user=\"app\"` |  |  |",
+		"",
+	}, "\n"), buf.String())
+}
+
+func TestRegistry_WriteHelp(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, testRegistry().WriteHelp(&buf))
+	require.Equal(t, strings.Join([]string{
+		"Environment variables:",
+		"  PORT         int     Port to listen on (default: 8080)",
+		"  MODE         string  One of dev|prod (required)",
+		"  DB_PASSWORD  string  Database password (secret, default: <secret>)",
+		"  DB_DSN       string  (default: host=localhost user=\"app\")",
+		"",
+	}, "\n"), buf.String())
+}
+
+func TestRegistry_WriteExample(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, testRegistry().WriteExample(&buf))
+	require.Equal(t, strings.Join([]string{
+		"# Port to listen on (int)",
+		"PORT=8080",
+		"",
+		"# One of dev|prod (string, required)",
+		"MODE=",
+		"",
+		"# Database password (string, secret)",
+		"DB_PASSWORD=",
+		"",
+		"# (string)",
+		`DB_DSN="host=localhost user=\"app\""`,
+		"",
+	}, "\n"), buf.String())
+
+	m, err := ParseDotenv(&buf)
+	require.NoError(t, err)
+	require.Equal(t, `host=localhost user="app"`, m["DB_DSN"])
+}
+
+func TestPrefix_Declare(t *testing.T) {
+	Prefix("FOO").Declare(Var{Name: "BAR", Type: "int"})
+
+	v, ok := DefaultRegistry.Lookup("FOO_BAR")
+	require.True(t, ok)
+	require.Equal(t, "int", v.Type)
+}
+
+func TestRegistry_DeclareStruct(t *testing.T) {
+	type db struct {
+		Password Secret `env:"PASSWORD" desc:"Database password" validate:"required"`
+		Token    string `env:"TOKEN,secret" desc:"API token"`
+	}
+	cfg := struct {
+		Port int    `env:"PORT" desc:"Port to listen on" validate:"min=1"`
+		Mode string `env:"MODE"`
+		DB   db     `env:"DB"`
+	}{Port: 8080, DB: db{Token: "default-token"}}
+
+	r := &Registry{}
+	require.NoError(t, r.DeclareStruct("APP", &cfg))
+	require.Equal(t, []Var{
+		{Name: "APP_PORT", Type: "int", Default: "8080", Description: "Port to listen on"},
+		{Name: "APP_MODE", Type: "string"},
+		{Name: "APP_DB_PASSWORD", Type: "env.Secret", Description: "Database password", Required: true, Secret: true},
+		{Name: "APP_DB_TOKEN", Type: "string", Default: "default-token", Description: "API token", Secret: true},
+	}, r.Vars())
+
+	var buf bytes.Buffer
+	require.NoError(t, r.WriteExample(&buf))
+	require.NotContains(t, buf.String(), "default-token")
+
+	formatted := struct {
+		Delays []time.Duration `env:"DELAYS"`
+		Limits map[string]int  `env:"LIMITS"`
+	}{[]time.Duration{time.Second, time.Minute}, map[string]int{"b": 2, "a": 1}}
+	require.NoError(t, r.DeclareStruct("", &formatted))
+	delays, _ := r.Lookup("DELAYS")
+	require.Equal(t, "1s,1m0s", delays.Default)
+	limits, _ := r.Lookup("LIMITS")
+	require.Equal(t, "a:1,b:2", limits.Default)
+
+	var invalid struct {
+		Token string `env:"TOKEN,secrte"`
+	}
+	require.EqualError(t, r.DeclareStruct("", &invalid), `env: field Token: unknown tag option "secrte"`)
+}
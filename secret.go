@@ -0,0 +1,100 @@
+package env
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+)
+
+// SecretMask is rendered in place of the value of a Secret.
+const SecretMask = "[REDACTED]"
+
+// Secret is a string whose value is masked whenever it is formatted, marshaled or logged, so it is not leaked by
+// printing a configuration struct. Reveal returns the value.
+type Secret string
+
+// Reveal returns the value of the Secret.
+func (s Secret) Reveal() string {
+	return string(s)
+}
+
+// String returns SecretMask.
+func (s Secret) String() string {
+	return SecretMask
+}
+
+// GoString returns SecretMask as a Secret literal, e.g. for the %#v verb.
+func (s Secret) GoString() string {
+	return "env.Secret(" + strconv.Quote(SecretMask) + ")"
+}
+
+// Format writes SecretMask for any verb, quoting it for %q.
+func (s Secret) Format(f fmt.State, verb rune) {
+	switch {
+	case verb == 'q':
+		fmt.Fprint(f, strconv.Quote(SecretMask))
+	case verb == 'v' && f.Flag('#'):
+		fmt.Fprint(f, s.GoString())
+	default:
+		fmt.Fprint(f, SecretMask)
+	}
+}
+
+// MarshalJSON encodes SecretMask as a JSON string.
+func (s Secret) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(SecretMask)), nil
+}
+
+// MarshalText returns SecretMask.
+func (s Secret) MarshalText() ([]byte, error) {
+	return []byte(SecretMask), nil
+}
+
+// LogValue implements slog.LogValuer, logging SecretMask.
+func (s Secret) LogValue() slog.Value {
+	return slog.StringValue(SecretMask)
+}
+
+// GetSecret retrieves a Secret named by key.
+// An empty Secret is returned if the value does not exist.
+func (e *Env) GetSecret(key string) Secret {
+	v := e.GetString(key)
+	e.secret(key)
+	return Secret(v)
+}
+
+// GetSecretD attempts to retrieve a Secret named by key. If the value is not present, def is returned instead.
+func (e *Env) GetSecretD(key string, def Secret) Secret {
+	// The lookup is recorded as a secret before the default is supplied, so the Tracker never holds def unmasked.
+	v := e.GetSecret(key)
+	defer supplyDefault(e, key, def)
+	if v != "" {
+		return v
+	}
+	return useDefault(e, key, def)
+}
+
+// GetSecretE retrieves a Secret named by key.
+// A *NotSetError is returned if the value is not present.
+func (e *Env) GetSecretE(key string) (Secret, error) {
+	v, err := e.lookupE(key, "env.Secret")
+	e.secret(key)
+	return Secret(v), err
+}
+
+// GetSecret retrieves a Secret named by key.
+// An empty Secret is returned if the value does not exist.
+func GetSecret(key string) Secret {
+	return Default.GetSecret(key)
+}
+
+// GetSecretD attempts to retrieve a Secret named by key. If the value is not present, def is returned instead.
+func GetSecretD(key string, def Secret) Secret {
+	return Default.GetSecretD(key, def)
+}
+
+// GetSecretE retrieves a Secret named by key.
+// A *NotSetError is returned if the value is not present.
+func GetSecretE(key string) (Secret, error) {
+	return Default.GetSecretE(key)
+}
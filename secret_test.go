@@ -0,0 +1,61 @@
+package env
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecret(t *testing.T) {
+	s := Secret("hunter2")
+
+	require.Equal(t, "hunter2", s.Reveal())
+	require.Equal(t, SecretMask, s.String())
+	require.Equal(t, SecretMask, fmt.Sprint(s))
+	require.Equal(t, SecretMask, fmt.Sprintf("%s", s))
+	require.Equal(t, SecretMask, fmt.Sprintf("%v", s))
+	require.Equal(t, `"[REDACTED]"`, fmt.Sprintf("%q", s))
+	require.Equal(t, SecretMask, fmt.Sprintf("%x", s))
+	require.Equal(t, `env.Secret("[REDACTED]")`, fmt.Sprintf("%#v", s))
+
+	cfg := struct {
+		User     string
+		Password Secret
+	}{User: "app", Password: s}
+	require.Equal(t, "{app [REDACTED]}", fmt.Sprintf("%v", cfg))
+	require.Equal(t, `{User:app Password:[REDACTED]}`, fmt.Sprintf("%+v", cfg))
+
+	b, err := json.Marshal(cfg)
+	require.NoError(t, err)
+	require.Equal(t, `{"User":"app","Password":"[REDACTED]"}`, string(b))
+
+	b, err = json.Marshal(map[Secret]int{s: 1})
+	require.NoError(t, err)
+	require.Equal(t, `{"[REDACTED]":1}`, string(b))
+
+	var buf bytes.Buffer
+	slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{})).Info("config", "password", s)
+	require.Contains(t, buf.String(), "password=[REDACTED]")
+	require.NotContains(t, buf.String(), "hunter2")
+}
+
+func TestGetSecret(t *testing.T) {
+	t.Setenv("FOO_PASSWORD", "hunter2")
+
+	require.Equal(t, "hunter2", GetSecret("FOO_PASSWORD").Reveal())
+	require.Equal(t, "hunter2", Prefix("FOO").GetSecret("PASSWORD").Reveal())
+	require.Equal(t, Secret("default"), Prefix("FOO").GetSecretD("MISSING", "default"))
+
+	_, err := GetSecretE("FOO_MISSING")
+	require.EqualError(t, err, "env: FOO_MISSING: not set, expected env.Secret")
+
+	var cfg struct {
+		Password Secret `env:"PASSWORD"`
+	}
+	require.NoError(t, Prefix("FOO").Unmarshal(&cfg))
+	require.Equal(t, "hunter2", cfg.Password.Reveal())
+}
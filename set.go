@@ -0,0 +1,227 @@
+package env
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+var errNotSetter = errors.New("env: Source does not implement Setter")
+
+// Set sets the value named by key. If e expands values, $ is escaped as $$, so value is retrieved unchanged rather
+// than expanded.
+// An error is returned if the Source of e does not implement Setter.
+func (e *Env) Set(key, value string) error {
+	s, ok := e.source().(Setter)
+	if !ok {
+		return errNotSetter
+	}
+	return s.Set(key, e.escape(value))
+}
+
+// Unset removes the value named by key.
+// An error is returned if the Source of e does not implement Setter.
+func (e *Env) Unset(key string) error {
+	s, ok := e.source().(Setter)
+	if !ok {
+		return errNotSetter
+	}
+	return s.Unset(key)
+}
+
+// Clear removes every variable with the prefix p.
+// An error is returned if the Source of e does not implement both Setter and Enumerator.
+func (e *Env) Clear(p Prefix) error {
+	if _, ok := e.source().(Enumerator); !ok {
+		return errors.New("env: Source does not implement Enumerator")
+	}
+	for _, name := range e.PrefixKeys(p) {
+		if err := e.Unset(p.format(name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetString sets the value named by key to a string.
+// It is functionally the same as Set.
+func (e *Env) SetString(key string, v string) error {
+	return e.Set(key, v)
+}
+
+// SetInt sets the value named by key to an int, formatted so that GetInt retrieves v.
+func (e *Env) SetInt(key string, v int) error {
+	return e.Set(key, strconv.Itoa(v))
+}
+
+// SetInt8 sets the value named by key to an int8, formatted so that GetInt8 retrieves v.
+func (e *Env) SetInt8(key string, v int8) error {
+	return e.Set(key, strconv.FormatInt(int64(v), 10))
+}
+
+// SetInt16 sets the value named by key to an int16, formatted so that GetInt16 retrieves v.
+func (e *Env) SetInt16(key string, v int16) error {
+	return e.Set(key, strconv.FormatInt(int64(v), 10))
+}
+
+// SetInt32 sets the value named by key to an int32, formatted so that GetInt32 retrieves v.
+func (e *Env) SetInt32(key string, v int32) error {
+	return e.Set(key, strconv.FormatInt(int64(v), 10))
+}
+
+// SetInt64 sets the value named by key to an int64, formatted so that GetInt64 retrieves v.
+func (e *Env) SetInt64(key string, v int64) error {
+	return e.Set(key, strconv.FormatInt(v, 10))
+}
+
+// SetUInt sets the value named by key to a uint, formatted so that GetUInt retrieves v.
+func (e *Env) SetUInt(key string, v uint) error {
+	return e.Set(key, strconv.FormatUint(uint64(v), 10))
+}
+
+// SetUInt8 sets the value named by key to a uint8, formatted so that GetUInt8 retrieves v.
+func (e *Env) SetUInt8(key string, v uint8) error {
+	return e.Set(key, strconv.FormatUint(uint64(v), 10))
+}
+
+// SetUInt16 sets the value named by key to a uint16, formatted so that GetUInt16 retrieves v.
+func (e *Env) SetUInt16(key string, v uint16) error {
+	return e.Set(key, strconv.FormatUint(uint64(v), 10))
+}
+
+// SetUInt32 sets the value named by key to a uint32, formatted so that GetUInt32 retrieves v.
+func (e *Env) SetUInt32(key string, v uint32) error {
+	return e.Set(key, strconv.FormatUint(uint64(v), 10))
+}
+
+// SetUInt64 sets the value named by key to a uint64, formatted so that GetUInt64 retrieves v.
+func (e *Env) SetUInt64(key string, v uint64) error {
+	return e.Set(key, strconv.FormatUint(v, 10))
+}
+
+// SetFloat32 sets the value named by key to a float32, formatted so that GetFloat32 retrieves v.
+func (e *Env) SetFloat32(key string, v float32) error {
+	return e.Set(key, strconv.FormatFloat(float64(v), 'g', -1, 32))
+}
+
+// SetFloat64 sets the value named by key to a float64, formatted so that GetFloat64 retrieves v.
+func (e *Env) SetFloat64(key string, v float64) error {
+	return e.Set(key, strconv.FormatFloat(v, 'g', -1, 64))
+}
+
+// SetBool sets the value named by key to a bool, formatted so that GetBool retrieves v.
+func (e *Env) SetBool(key string, v bool) error {
+	return e.Set(key, strconv.FormatBool(v))
+}
+
+// SetDuration sets the value named by key to a time.Duration, formatted so that GetDuration retrieves v.
+func (e *Env) SetDuration(key string, v time.Duration) error {
+	return e.Set(key, v.String())
+}
+
+// SetAs sets the value named by key in Default to a T, formatted so that Parse retrieves v.
+// See SetAsIn for details.
+func SetAs[T any](key string, v T) error {
+	return SetAsIn(Default, key, v)
+}
+
+// SetAsIn sets the value named by key in s to a T, formatted so that ParseIn retrieves v.
+// T may be any type supported by Parse that can be formatted, as described by Env.MarshalMap.
+func SetAsIn[T any](s Scope, key string, v T) error {
+	e, key := s.scope(key)
+	value, err := e.format(reflect.ValueOf(&v).Elem())
+	if err == errUnsupportedType {
+		return fmt.Errorf("env: %s: unsupported type %s", key, typeOf[T]())
+	} else if err != nil {
+		return fmt.Errorf("env: %s: %w", key, err)
+	}
+	return e.Set(key, value)
+}
+
+// Set sets the value named by key in Default, which modifies the process environment unless its Source is replaced.
+func Set(key, value string) error {
+	return Default.Set(key, value)
+}
+
+// Unset removes the value named by key from Default.
+func Unset(key string) error {
+	return Default.Unset(key)
+}
+
+// SetString sets the value named by key in Default to a string.
+// It is functionally the same as Set.
+func SetString(key string, v string) error {
+	return Default.SetString(key, v)
+}
+
+// SetInt sets the value named by key in Default to an int, formatted so that GetInt retrieves v.
+func SetInt(key string, v int) error {
+	return Default.SetInt(key, v)
+}
+
+// SetInt8 sets the value named by key in Default to an int8, formatted so that GetInt8 retrieves v.
+func SetInt8(key string, v int8) error {
+	return Default.SetInt8(key, v)
+}
+
+// SetInt16 sets the value named by key in Default to an int16, formatted so that GetInt16 retrieves v.
+func SetInt16(key string, v int16) error {
+	return Default.SetInt16(key, v)
+}
+
+// SetInt32 sets the value named by key in Default to an int32, formatted so that GetInt32 retrieves v.
+func SetInt32(key string, v int32) error {
+	return Default.SetInt32(key, v)
+}
+
+// SetInt64 sets the value named by key in Default to an int64, formatted so that GetInt64 retrieves v.
+func SetInt64(key string, v int64) error {
+	return Default.SetInt64(key, v)
+}
+
+// SetUInt sets the value named by key in Default to a uint, formatted so that GetUInt retrieves v.
+func SetUInt(key string, v uint) error {
+	return Default.SetUInt(key, v)
+}
+
+// SetUInt8 sets the value named by key in Default to a uint8, formatted so that GetUInt8 retrieves v.
+func SetUInt8(key string, v uint8) error {
+	return Default.SetUInt8(key, v)
+}
+
+// SetUInt16 sets the value named by key in Default to a uint16, formatted so that GetUInt16 retrieves v.
+func SetUInt16(key string, v uint16) error {
+	return Default.SetUInt16(key, v)
+}
+
+// SetUInt32 sets the value named by key in Default to a uint32, formatted so that GetUInt32 retrieves v.
+func SetUInt32(key string, v uint32) error {
+	return Default.SetUInt32(key, v)
+}
+
+// SetUInt64 sets the value named by key in Default to a uint64, formatted so that GetUInt64 retrieves v.
+func SetUInt64(key string, v uint64) error {
+	return Default.SetUInt64(key, v)
+}
+
+// SetFloat32 sets the value named by key in Default to a float32, formatted so that GetFloat32 retrieves v.
+func SetFloat32(key string, v float32) error {
+	return Default.SetFloat32(key, v)
+}
+
+// SetFloat64 sets the value named by key in Default to a float64, formatted so that GetFloat64 retrieves v.
+func SetFloat64(key string, v float64) error {
+	return Default.SetFloat64(key, v)
+}
+
+// SetBool sets the value named by key in Default to a bool, formatted so that GetBool retrieves v.
+func SetBool(key string, v bool) error {
+	return Default.SetBool(key, v)
+}
+
+// SetDuration sets the value named by key in Default to a time.Duration, formatted so that GetDuration retrieves v.
+func SetDuration(key string, v time.Duration) error {
+	return Default.SetDuration(key, v)
+}
@@ -0,0 +1,128 @@
+package env
+
+import (
+	"math"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnv_Set(t *testing.T) {
+	e := New(Map{})
+
+	require.NoError(t, e.SetString("STRING", " padded "))
+	require.NoError(t, e.SetInt("INT", math.MinInt))
+	require.NoError(t, e.SetInt8("INT8", math.MinInt8))
+	require.NoError(t, e.SetInt16("INT16", math.MinInt16))
+	require.NoError(t, e.SetInt32("INT32", math.MinInt32))
+	require.NoError(t, e.SetInt64("INT64", math.MinInt64))
+	require.NoError(t, e.SetUInt("UINT", math.MaxUint))
+	require.NoError(t, e.SetUInt8("UINT8", math.MaxUint8))
+	require.NoError(t, e.SetUInt16("UINT16", math.MaxUint16))
+	require.NoError(t, e.SetUInt32("UINT32", math.MaxUint32))
+	require.NoError(t, e.SetUInt64("UINT64", math.MaxUint64))
+	require.NoError(t, e.SetFloat32("FLOAT32", math.SmallestNonzeroFloat32))
+	require.NoError(t, e.SetFloat64("FLOAT64", 0.1+0.2))
+	require.NoError(t, e.SetBool("BOOL", true))
+	require.NoError(t, e.SetDuration("DURATION", 90*time.Minute+time.Nanosecond))
+
+	require.Equal(t, " padded ", e.GetString("STRING"))
+	require.Equal(t, math.MinInt, e.GetInt("INT"))
+	require.Equal(t, int8(math.MinInt8), e.GetInt8("INT8"))
+	require.Equal(t, int16(math.MinInt16), e.GetInt16("INT16"))
+	require.Equal(t, int32(math.MinInt32), e.GetInt32("INT32"))
+	require.Equal(t, int64(math.MinInt64), e.GetInt64("INT64"))
+	require.Equal(t, uint(math.MaxUint), e.GetUInt("UINT"))
+	require.Equal(t, uint8(math.MaxUint8), e.GetUInt8("UINT8"))
+	require.Equal(t, uint16(math.MaxUint16), e.GetUInt16("UINT16"))
+	require.Equal(t, uint32(math.MaxUint32), e.GetUInt32("UINT32"))
+	require.Equal(t, uint64(math.MaxUint64), e.GetUInt64("UINT64"))
+	require.Equal(t, float32(math.SmallestNonzeroFloat32), e.GetFloat32("FLOAT32"))
+	require.Equal(t, 0.1+0.2, e.GetFloat64("FLOAT64"))
+	require.Equal(t, true, e.GetBool("BOOL"))
+	require.Equal(t, 90*time.Minute+time.Nanosecond, e.GetDuration("DURATION"))
+
+	require.NoError(t, e.Unset("BOOL"))
+	_, ok := e.Lookup("BOOL")
+	require.False(t, ok)
+}
+
+func TestSetAsIn(t *testing.T) {
+	e := New(Map{})
+
+	hosts := []string{"a", "b,c"}
+	require.NoError(t, SetAsIn(e, "HOSTS", hosts))
+	got, err := ParseIn[[]string](e, "HOSTS")
+	require.NoError(t, err)
+	require.Equal(t, hosts, got)
+
+	require.NoError(t, SetAsIn(Namespace{Env: e, Separator: "."}.Sub("app"), "port", genericPort(8080)))
+	require.Equal(t, "8080", e.Get("app.port"))
+
+	require.EqualError(t, SetAsIn(e, "C", make(chan int)), "env: C: unsupported type chan int")
+}
+
+func TestEnv_Set_Expand(t *testing.T) {
+	m := Map{}
+	e := &Env{Source: m, Expand: true}
+
+	require.NoError(t, e.SetString("DSN", "user:pa$$word@$HOST"))
+	require.Equal(t, "user:pa$$$$word@$$HOST", m["DSN"])
+	require.Equal(t, "user:pa$$word@$HOST", e.GetString("DSN"))
+
+	hosts := []string{"a$b", "${c}"}
+	require.NoError(t, SetAsIn(e, "HOSTS", hosts))
+	got, err := ParseIn[[]string](e, "HOSTS")
+	require.NoError(t, err)
+	require.Equal(t, hosts, got)
+}
+
+func TestEnv_Set_NotSetter(t *testing.T) {
+	e := New(SourceFunc(func(string) (string, bool) { return "", false }))
+	require.Error(t, e.SetInt("FOO", 1))
+	require.Error(t, e.Unset("FOO"))
+	require.Error(t, e.Clear("FOO"))
+}
+
+func TestEnv_Clear(t *testing.T) {
+	m := Map{"APP_A": "1", "APP_B": "2", "APPS": "3", "OTHER": "4"}
+	require.NoError(t, New(m).Clear("APP"))
+	require.Equal(t, Map{"APPS": "3", "OTHER": "4"}, m)
+}
+
+func TestPrefix_Set(t *testing.T) {
+	p := Prefix("SETTEST")
+	defer p.Clear()
+
+	require.NoError(t, p.SetDuration("TIMEOUT", 1500*time.Millisecond))
+	require.NoError(t, p.SetBool("DEBUG", false))
+	require.NoError(t, p.SetFloat64("RATIO", 1e-9))
+	require.NoError(t, p.Set("NAME", "app"))
+
+	require.Equal(t, "1.5s", os.Getenv("SETTEST_TIMEOUT"))
+	require.Equal(t, 1500*time.Millisecond, p.GetDuration("TIMEOUT"))
+	require.Equal(t, false, p.GetBoolD("DEBUG", true))
+	require.Equal(t, 1e-9, p.GetFloat64("RATIO"))
+	require.Equal(t, "app", p.Get("NAME"))
+
+	require.NoError(t, p.Unset("NAME"))
+	_, ok := p.Lookup("NAME")
+	require.False(t, ok)
+
+	require.NoError(t, p.Clear())
+	require.Empty(t, p.Keys())
+}
+
+func TestSet(t *testing.T) {
+	defer Unset("SETTEST_PORT")
+
+	require.NoError(t, SetUInt16("SETTEST_PORT", 8080))
+	require.Equal(t, uint16(8080), GetUInt16("SETTEST_PORT"))
+	require.NoError(t, SetAs("SETTEST_PORT", 9090))
+	require.Equal(t, 9090, GetAs[int]("SETTEST_PORT"))
+	require.NoError(t, Unset("SETTEST_PORT"))
+	_, ok := os.LookupEnv("SETTEST_PORT")
+	require.False(t, ok)
+}
@@ -0,0 +1,468 @@
+package env
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// DefaultListSeparator is the ListSeparator used by an Env that does not set one.
+const DefaultListSeparator = ","
+
+// GetStrings retrieves a []string named by key, splitting the value on the ListSeparator.
+// A nil slice is returned if the value does not exist or contains an unterminated quoted element.
+func (e *Env) GetStrings(key string) []string {
+	v, _ := e.GetStringsE(key)
+	return v
+}
+
+// GetStringsD attempts to retrieve a []string named by key. If the value is not present or is not valid, def is returned instead.
+func (e *Env) GetStringsD(key string, def []string) []string {
+	defer supplyDefault(e, key, def)
+	v, err := e.GetStringsE(key)
+	if err != nil {
+		return useDefault(e, key, def)
+	}
+	return v
+}
+
+// GetStringsE retrieves a []string named by key, splitting the value on the ListSeparator.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it contains a malformed quoted element.
+func (e *Env) GetStringsE(key string) ([]string, error) {
+	_, elems, err := e.lookupListE(key, "[]string")
+	return elems, err
+}
+
+// GetInts retrieves a []int named by key, splitting the value on the ListSeparator.
+// A nil slice is returned if the value does not exist or any element is not a valid int.
+func (e *Env) GetInts(key string) []int {
+	v, _ := e.GetIntsE(key)
+	return v
+}
+
+// GetIntsD attempts to retrieve a []int named by key. If the value is not present or is not valid, def is returned instead.
+func (e *Env) GetIntsD(key string, def []int) []int {
+	defer supplyDefault(e, key, def)
+	v, err := e.GetIntsE(key)
+	if err != nil {
+		return useDefault(e, key, def)
+	}
+	return v
+}
+
+// GetIntsE retrieves a []int named by key, splitting the value on the ListSeparator.
+// A *NotSetError is returned if the value is not present, or a *ParseError if any element is not a valid int.
+func (e *Env) GetIntsE(key string) ([]int, error) {
+	raw, elems, err := e.lookupListE(key, "[]int")
+	if err != nil {
+		return nil, err
+	}
+	v := make([]int, len(elems))
+	for i, s := range elems {
+		if v[i], err = parseInt(s); err != nil {
+			return nil, e.elementError(key, raw, "[]int", i, s, err)
+		}
+	}
+	return v, nil
+}
+
+// GetInt64s retrieves a []int64 named by key, splitting the value on the ListSeparator.
+// A nil slice is returned if the value does not exist or any element is not a valid int64.
+func (e *Env) GetInt64s(key string) []int64 {
+	v, _ := e.GetInt64sE(key)
+	return v
+}
+
+// GetInt64sD attempts to retrieve a []int64 named by key. If the value is not present or is not valid, def is returned instead.
+func (e *Env) GetInt64sD(key string, def []int64) []int64 {
+	defer supplyDefault(e, key, def)
+	v, err := e.GetInt64sE(key)
+	if err != nil {
+		return useDefault(e, key, def)
+	}
+	return v
+}
+
+// GetInt64sE retrieves a []int64 named by key, splitting the value on the ListSeparator.
+// A *NotSetError is returned if the value is not present, or a *ParseError if any element is not a valid int64.
+func (e *Env) GetInt64sE(key string) ([]int64, error) {
+	raw, elems, err := e.lookupListE(key, "[]int64")
+	if err != nil {
+		return nil, err
+	}
+	v := make([]int64, len(elems))
+	for i, s := range elems {
+		if v[i], err = parseInt64(s); err != nil {
+			return nil, e.elementError(key, raw, "[]int64", i, s, err)
+		}
+	}
+	return v, nil
+}
+
+// GetUInts retrieves a []uint named by key, splitting the value on the ListSeparator.
+// A nil slice is returned if the value does not exist or any element is not a valid uint.
+func (e *Env) GetUInts(key string) []uint {
+	v, _ := e.GetUIntsE(key)
+	return v
+}
+
+// GetUIntsD attempts to retrieve a []uint named by key. If the value is not present or is not valid, def is returned instead.
+func (e *Env) GetUIntsD(key string, def []uint) []uint {
+	defer supplyDefault(e, key, def)
+	v, err := e.GetUIntsE(key)
+	if err != nil {
+		return useDefault(e, key, def)
+	}
+	return v
+}
+
+// GetUIntsE retrieves a []uint named by key, splitting the value on the ListSeparator.
+// A *NotSetError is returned if the value is not present, or a *ParseError if any element is not a valid uint.
+func (e *Env) GetUIntsE(key string) ([]uint, error) {
+	raw, elems, err := e.lookupListE(key, "[]uint")
+	if err != nil {
+		return nil, err
+	}
+	v := make([]uint, len(elems))
+	for i, s := range elems {
+		if v[i], err = parseUInt(s); err != nil {
+			return nil, e.elementError(key, raw, "[]uint", i, s, err)
+		}
+	}
+	return v, nil
+}
+
+// GetUInt64s retrieves a []uint64 named by key, splitting the value on the ListSeparator.
+// A nil slice is returned if the value does not exist or any element is not a valid uint64.
+func (e *Env) GetUInt64s(key string) []uint64 {
+	v, _ := e.GetUInt64sE(key)
+	return v
+}
+
+// GetUInt64sD attempts to retrieve a []uint64 named by key. If the value is not present or is not valid, def is returned instead.
+func (e *Env) GetUInt64sD(key string, def []uint64) []uint64 {
+	defer supplyDefault(e, key, def)
+	v, err := e.GetUInt64sE(key)
+	if err != nil {
+		return useDefault(e, key, def)
+	}
+	return v
+}
+
+// GetUInt64sE retrieves a []uint64 named by key, splitting the value on the ListSeparator.
+// A *NotSetError is returned if the value is not present, or a *ParseError if any element is not a valid uint64.
+func (e *Env) GetUInt64sE(key string) ([]uint64, error) {
+	raw, elems, err := e.lookupListE(key, "[]uint64")
+	if err != nil {
+		return nil, err
+	}
+	v := make([]uint64, len(elems))
+	for i, s := range elems {
+		if v[i], err = parseUInt64(s); err != nil {
+			return nil, e.elementError(key, raw, "[]uint64", i, s, err)
+		}
+	}
+	return v, nil
+}
+
+// GetFloat64s retrieves a []float64 named by key, splitting the value on the ListSeparator.
+// A nil slice is returned if the value does not exist or any element is not a valid float64.
+func (e *Env) GetFloat64s(key string) []float64 {
+	v, _ := e.GetFloat64sE(key)
+	return v
+}
+
+// GetFloat64sD attempts to retrieve a []float64 named by key. If the value is not present or is not valid, def is returned instead.
+func (e *Env) GetFloat64sD(key string, def []float64) []float64 {
+	defer supplyDefault(e, key, def)
+	v, err := e.GetFloat64sE(key)
+	if err != nil {
+		return useDefault(e, key, def)
+	}
+	return v
+}
+
+// GetFloat64sE retrieves a []float64 named by key, splitting the value on the ListSeparator.
+// A *NotSetError is returned if the value is not present, or a *ParseError if any element is not a valid float64.
+func (e *Env) GetFloat64sE(key string) ([]float64, error) {
+	raw, elems, err := e.lookupListE(key, "[]float64")
+	if err != nil {
+		return nil, err
+	}
+	v := make([]float64, len(elems))
+	for i, s := range elems {
+		if v[i], err = parseFloat64(s); err != nil {
+			return nil, e.elementError(key, raw, "[]float64", i, s, err)
+		}
+	}
+	return v, nil
+}
+
+// GetBools retrieves a []bool named by key, splitting the value on the ListSeparator.
+// A nil slice is returned if the value does not exist or any element is not a valid bool.
+func (e *Env) GetBools(key string) []bool {
+	v, _ := e.GetBoolsE(key)
+	return v
+}
+
+// GetBoolsD attempts to retrieve a []bool named by key. If the value is not present or is not valid, def is returned instead.
+func (e *Env) GetBoolsD(key string, def []bool) []bool {
+	defer supplyDefault(e, key, def)
+	v, err := e.GetBoolsE(key)
+	if err != nil {
+		return useDefault(e, key, def)
+	}
+	return v
+}
+
+// GetBoolsE retrieves a []bool named by key, splitting the value on the ListSeparator.
+// A *NotSetError is returned if the value is not present, or a *ParseError if any element is not a valid bool.
+func (e *Env) GetBoolsE(key string) ([]bool, error) {
+	raw, elems, err := e.lookupListE(key, "[]bool")
+	if err != nil {
+		return nil, err
+	}
+	v := make([]bool, len(elems))
+	for i, s := range elems {
+		if v[i], err = parseBool(s); err != nil {
+			return nil, e.elementError(key, raw, "[]bool", i, s, err)
+		}
+	}
+	return v, nil
+}
+
+// GetDurations retrieves a []time.Duration named by key, splitting the value on the ListSeparator.
+// A nil slice is returned if the value does not exist or any element is not a valid time.Duration.
+func (e *Env) GetDurations(key string) []time.Duration {
+	v, _ := e.GetDurationsE(key)
+	return v
+}
+
+// GetDurationsD attempts to retrieve a []time.Duration named by key. If the value is not present or is not valid, def is returned instead.
+func (e *Env) GetDurationsD(key string, def []time.Duration) []time.Duration {
+	defer supplyDefault(e, key, def)
+	v, err := e.GetDurationsE(key)
+	if err != nil {
+		return useDefault(e, key, def)
+	}
+	return v
+}
+
+// GetDurationsE retrieves a []time.Duration named by key, splitting the value on the ListSeparator.
+// A *NotSetError is returned if the value is not present, or a *ParseError if any element is not a valid time.Duration.
+func (e *Env) GetDurationsE(key string) ([]time.Duration, error) {
+	raw, elems, err := e.lookupListE(key, "[]time.Duration")
+	if err != nil {
+		return nil, err
+	}
+	v := make([]time.Duration, len(elems))
+	for i, s := range elems {
+		if v[i], err = parseDuration(s); err != nil {
+			return nil, e.elementError(key, raw, "[]time.Duration", i, s, err)
+		}
+	}
+	return v, nil
+}
+
+func (e *Env) listSeparator() string {
+	if e.ListSeparator == "" {
+		return DefaultListSeparator
+	}
+	return e.ListSeparator
+}
+
+func (e *Env) lookupListE(key, typ string) (string, []string, error) {
+	raw, err := e.lookupE(key, typ)
+	if err != nil {
+		return "", nil, err
+	}
+	elems, err := splitList(raw, e.listSeparator())
+	if err != nil {
+		return "", nil, e.parseError(key, raw, typ, err)
+	}
+	return raw, elems, nil
+}
+
+// GetStrings retrieves a []string named by key, splitting the value on the ListSeparator.
+// A nil slice is returned if the value does not exist or contains an unterminated quoted element.
+func GetStrings(key string) []string {
+	return Default.GetStrings(key)
+}
+
+// GetStringsD attempts to retrieve a []string named by key. If the value is not present or is not valid, def is returned instead.
+func GetStringsD(key string, def []string) []string {
+	return Default.GetStringsD(key, def)
+}
+
+// GetStringsE retrieves a []string named by key, splitting the value on the ListSeparator.
+// A *NotSetError is returned if the value is not present, or a *ParseError if it contains a malformed quoted element.
+func GetStringsE(key string) ([]string, error) {
+	return Default.GetStringsE(key)
+}
+
+// GetInts retrieves a []int named by key, splitting the value on the ListSeparator.
+// A nil slice is returned if the value does not exist or any element is not a valid int.
+func GetInts(key string) []int {
+	return Default.GetInts(key)
+}
+
+// GetIntsD attempts to retrieve a []int named by key. If the value is not present or is not valid, def is returned instead.
+func GetIntsD(key string, def []int) []int {
+	return Default.GetIntsD(key, def)
+}
+
+// GetIntsE retrieves a []int named by key, splitting the value on the ListSeparator.
+// A *NotSetError is returned if the value is not present, or a *ParseError if any element is not a valid int.
+func GetIntsE(key string) ([]int, error) {
+	return Default.GetIntsE(key)
+}
+
+// GetInt64s retrieves a []int64 named by key, splitting the value on the ListSeparator.
+// A nil slice is returned if the value does not exist or any element is not a valid int64.
+func GetInt64s(key string) []int64 {
+	return Default.GetInt64s(key)
+}
+
+// GetInt64sD attempts to retrieve a []int64 named by key. If the value is not present or is not valid, def is returned instead.
+func GetInt64sD(key string, def []int64) []int64 {
+	return Default.GetInt64sD(key, def)
+}
+
+// GetInt64sE retrieves a []int64 named by key, splitting the value on the ListSeparator.
+// A *NotSetError is returned if the value is not present, or a *ParseError if any element is not a valid int64.
+func GetInt64sE(key string) ([]int64, error) {
+	return Default.GetInt64sE(key)
+}
+
+// GetUInts retrieves a []uint named by key, splitting the value on the ListSeparator.
+// A nil slice is returned if the value does not exist or any element is not a valid uint.
+func GetUInts(key string) []uint {
+	return Default.GetUInts(key)
+}
+
+// GetUIntsD attempts to retrieve a []uint named by key. If the value is not present or is not valid, def is returned instead.
+func GetUIntsD(key string, def []uint) []uint {
+	return Default.GetUIntsD(key, def)
+}
+
+// GetUIntsE retrieves a []uint named by key, splitting the value on the ListSeparator.
+// A *NotSetError is returned if the value is not present, or a *ParseError if any element is not a valid uint.
+func GetUIntsE(key string) ([]uint, error) {
+	return Default.GetUIntsE(key)
+}
+
+// GetUInt64s retrieves a []uint64 named by key, splitting the value on the ListSeparator.
+// A nil slice is returned if the value does not exist or any element is not a valid uint64.
+func GetUInt64s(key string) []uint64 {
+	return Default.GetUInt64s(key)
+}
+
+// GetUInt64sD attempts to retrieve a []uint64 named by key. If the value is not present or is not valid, def is returned instead.
+func GetUInt64sD(key string, def []uint64) []uint64 {
+	return Default.GetUInt64sD(key, def)
+}
+
+// GetUInt64sE retrieves a []uint64 named by key, splitting the value on the ListSeparator.
+// A *NotSetError is returned if the value is not present, or a *ParseError if any element is not a valid uint64.
+func GetUInt64sE(key string) ([]uint64, error) {
+	return Default.GetUInt64sE(key)
+}
+
+// GetFloat64s retrieves a []float64 named by key, splitting the value on the ListSeparator.
+// A nil slice is returned if the value does not exist or any element is not a valid float64.
+func GetFloat64s(key string) []float64 {
+	return Default.GetFloat64s(key)
+}
+
+// GetFloat64sD attempts to retrieve a []float64 named by key. If the value is not present or is not valid, def is returned instead.
+func GetFloat64sD(key string, def []float64) []float64 {
+	return Default.GetFloat64sD(key, def)
+}
+
+// GetFloat64sE retrieves a []float64 named by key, splitting the value on the ListSeparator.
+// A *NotSetError is returned if the value is not present, or a *ParseError if any element is not a valid float64.
+func GetFloat64sE(key string) ([]float64, error) {
+	return Default.GetFloat64sE(key)
+}
+
+// GetBools retrieves a []bool named by key, splitting the value on the ListSeparator.
+// A nil slice is returned if the value does not exist or any element is not a valid bool.
+func GetBools(key string) []bool {
+	return Default.GetBools(key)
+}
+
+// GetBoolsD attempts to retrieve a []bool named by key. If the value is not present or is not valid, def is returned instead.
+func GetBoolsD(key string, def []bool) []bool {
+	return Default.GetBoolsD(key, def)
+}
+
+// GetBoolsE retrieves a []bool named by key, splitting the value on the ListSeparator.
+// A *NotSetError is returned if the value is not present, or a *ParseError if any element is not a valid bool.
+func GetBoolsE(key string) ([]bool, error) {
+	return Default.GetBoolsE(key)
+}
+
+// GetDurations retrieves a []time.Duration named by key, splitting the value on the ListSeparator.
+// A nil slice is returned if the value does not exist or any element is not a valid time.Duration.
+func GetDurations(key string) []time.Duration {
+	return Default.GetDurations(key)
+}
+
+// GetDurationsD attempts to retrieve a []time.Duration named by key. If the value is not present or is not valid, def is returned instead.
+func GetDurationsD(key string, def []time.Duration) []time.Duration {
+	return Default.GetDurationsD(key, def)
+}
+
+// GetDurationsE retrieves a []time.Duration named by key, splitting the value on the ListSeparator.
+// A *NotSetError is returned if the value is not present, or a *ParseError if any element is not a valid time.Duration.
+func GetDurationsE(key string) ([]time.Duration, error) {
+	return Default.GetDurationsE(key)
+}
+
+// splitList splits s on sep, trimming the whitespace surrounding each element.
+// An element enclosed in single or double quotes may contain sep, and the quotes are removed.
+func splitList(s, sep string) ([]string, error) {
+	var (
+		elems []string
+		sb    strings.Builder
+		quote byte
+		// quoted reports whether the current element was quoted, so its whitespace is preserved.
+		quoted bool
+	)
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+				continue
+			}
+			sb.WriteByte(c)
+		case (c == '"' || c == '\'') && strings.TrimSpace(sb.String()) == "" && !quoted:
+			sb.Reset()
+			quote, quoted = c, true
+		case strings.HasPrefix(s[i:], sep):
+			elems = append(elems, trimElement(sb.String(), quoted))
+			sb.Reset()
+			quoted = false
+			i += len(sep) - 1
+		case quoted:
+			if !isSpace(c) {
+				return nil, errors.New("unexpected characters after quoted element")
+			}
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	if quote != 0 {
+		return nil, errors.New("unterminated quoted element")
+	}
+	return append(elems, trimElement(sb.String(), quoted)), nil
+}
+
+func trimElement(s string, quoted bool) string {
+	if quoted {
+		return s
+	}
+	return strings.TrimSpace(s)
+}
@@ -0,0 +1,77 @@
+package env
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSlice(t *testing.T) {
+	e := New(Map{
+		"HOSTS":     " a.com, b.com ,c.com",
+		"QUOTED":    `a, "b, c" , 'd e' ,f`,
+		"PORTS":     "80,443",
+		"INT64S":    "-1,2",
+		"UINTS":     "1, 2",
+		"UINT64S":   "3",
+		"FLOATS":    "0.5,1.5",
+		"BOOLS":     "yes,no,1",
+		"DURATIONS": "1s, 1m",
+		"PIPES":     "1|2|3",
+		"BAD":       "80,x,443",
+	})
+
+	require.Equal(t, []string{"a.com", "b.com", "c.com"}, e.GetStrings("HOSTS"))
+	require.Equal(t, []string{"a", "b, c", "d e", "f"}, e.GetStrings("QUOTED"))
+	require.Equal(t, []int{80, 443}, e.GetInts("PORTS"))
+	require.Equal(t, []int64{-1, 2}, e.GetInt64s("INT64S"))
+	require.Equal(t, []uint{1, 2}, e.GetUInts("UINTS"))
+	require.Equal(t, []uint64{3}, e.GetUInt64s("UINT64S"))
+	require.Equal(t, []float64{0.5, 1.5}, e.GetFloat64s("FLOATS"))
+	require.Equal(t, []bool{true, false, true}, e.GetBools("BOOLS"))
+	require.Equal(t, []time.Duration{time.Second, time.Minute}, e.GetDurations("DURATIONS"))
+	require.Equal(t, []int{1}, e.GetIntsD("MISSING", []int{1}))
+	require.Equal(t, []int{1}, e.GetIntsD("BAD", []int{1}))
+	require.Nil(t, e.GetInts("BAD"))
+
+	e.ListSeparator = "|"
+	require.Equal(t, []int{1, 2, 3}, e.GetInts("PIPES"))
+}
+
+func TestGetSliceE(t *testing.T) {
+	e := New(Map{
+		"BAD":        "80,x,443",
+		"UNBALANCED": `a,"b`,
+		"TRAILING":   `"a" b,c`,
+	})
+
+	_, err := e.GetIntsE("BAD")
+	var parseErr *ParseError
+	require.True(t, errors.As(err, &parseErr))
+	require.Equal(t, "[]int", parseErr.Type)
+	var elemErr *ElementError
+	require.True(t, errors.As(err, &elemErr))
+	require.Equal(t, &ElementError{Index: 1, Value: "x", Err: strconv.ErrSyntax}, elemErr)
+	require.EqualError(t, err, `env: BAD: parsing "80,x,443" as []int: element 1 "x": invalid syntax`)
+
+	_, err = e.GetStringsE("UNBALANCED")
+	require.True(t, errors.As(err, &parseErr))
+
+	_, err = e.GetStringsE("TRAILING")
+	require.True(t, errors.As(err, &parseErr))
+
+	_, err = e.GetDurationsE("MISSING")
+	require.True(t, errors.Is(err, ErrNotSet))
+}
+
+func TestPrefix_GetSlice(t *testing.T) {
+	t.Setenv("FOO_PORTS", "80, 443")
+
+	prefix := Prefix("FOO")
+	require.Equal(t, []int{80, 443}, prefix.GetInts("PORTS"))
+	require.Equal(t, []string{"80", "443"}, prefix.GetStringsD("PORTS", nil))
+	require.Equal(t, []bool{true}, prefix.GetBoolsD("MISSING", []bool{true}))
+}
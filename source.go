@@ -0,0 +1,120 @@
+package env
+
+import (
+	"os"
+	"sort"
+	"strings"
+)
+
+// Source provides the values of named variables.
+type Source interface {
+	// Lookup retrieves the value named by key and reports whether it is present.
+	Lookup(key string) (string, bool)
+}
+
+// Enumerator is optionally implemented by a Source that can list the keys it contains.
+type Enumerator interface {
+	// Keys returns the keys present in the Source in sorted order.
+	Keys() []string
+}
+
+// Locator is optionally implemented by a Source that can report where its variables are defined, e.g. DotenvFile.
+type Locator interface {
+	// Locate returns the name of the file and the 1-based line the variable named by key is defined on.
+	Locate(key string) (file string, line int, ok bool)
+}
+
+// Setter is optionally implemented by a Source whose variables can be modified, e.g. OS and Map.
+type Setter interface {
+	// Set sets the value named by key.
+	Set(key, value string) error
+	// Unset removes the value named by key.
+	Unset(key string) error
+}
+
+// OS is a Source backed by the environment of the current process.
+var OS Source = osSource{}
+
+type osSource struct{}
+
+func (osSource) Lookup(key string) (string, bool) {
+	return os.LookupEnv(key)
+}
+
+func (osSource) Set(key, value string) error {
+	return os.Setenv(key, value)
+}
+
+func (osSource) Unset(key string) error {
+	return os.Unsetenv(key)
+}
+
+func (osSource) Keys() []string {
+	environ := os.Environ()
+	keys := make([]string, 0, len(environ))
+	for _, kv := range environ {
+		if i := strings.IndexByte(kv, '='); i > 0 {
+			keys = append(keys, kv[:i])
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Map is a Source backed by a map of keys to values.
+type Map map[string]string
+
+// Lookup retrieves the value named by key and reports whether it is present.
+func (m Map) Lookup(key string) (string, bool) {
+	v, ok := m[key]
+	return v, ok
+}
+
+// Set sets the value named by key.
+func (m Map) Set(key, value string) error {
+	m[key] = value
+	return nil
+}
+
+// Unset removes the value named by key.
+func (m Map) Unset(key string) error {
+	delete(m, key)
+	return nil
+}
+
+// Keys returns the keys present in the Map in sorted order.
+func (m Map) Keys() []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Environ returns the variables of m as KEY=value pairs sorted by key, the format of os.Environ.
+func (m Map) Environ() []string {
+	environ := make([]string, 0, len(m))
+	for _, k := range m.Keys() {
+		environ = append(environ, k+"="+m[k])
+	}
+	return environ
+}
+
+// SourceFunc adapts a lookup function to a Source.
+type SourceFunc func(key string) (string, bool)
+
+// Lookup calls f(key).
+func (f SourceFunc) Lookup(key string) (string, bool) {
+	return f(key)
+}
+
+// Keys returns the keys present in src in sorted order.
+// ok is false if src does not implement Enumerator.
+func Keys(src Source) (keys []string, ok bool) {
+	e, ok := src.(Enumerator)
+	if !ok {
+		return nil, false
+	}
+	return e.Keys(), true
+}
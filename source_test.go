@@ -0,0 +1,57 @@
+package env
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnv_Map(t *testing.T) {
+	e := New(Map{
+		"PORT":    "8080",
+		"TIMEOUT": "5s",
+		"DEBUG":   "yes",
+		"RETRIES": "0",
+	})
+
+	require.Equal(t, 8080, e.GetInt("PORT"))
+	require.Equal(t, uint16(8080), e.GetUInt16D("PORT", 80))
+	require.Equal(t, 5*time.Second, e.GetDuration("TIMEOUT"))
+	require.Equal(t, true, e.GetBool("DEBUG"))
+	require.Equal(t, 0, e.GetIntD("RETRIES", 3))
+	require.Equal(t, "fallback", e.GetD("MISSING", "fallback"))
+
+	_, ok := e.Lookup("MISSING")
+	require.False(t, ok)
+
+	keys, ok := Keys(e.Source)
+	require.True(t, ok)
+	require.Equal(t, []string{"DEBUG", "PORT", "RETRIES", "TIMEOUT"}, keys)
+}
+
+func TestEnv_Zero(t *testing.T) {
+	t.Setenv("FOO", "BAR")
+
+	var e Env
+	require.Equal(t, "BAR", e.Get("FOO"))
+}
+
+func TestOS_Keys(t *testing.T) {
+	t.Setenv("FOO", "BAR")
+
+	keys, ok := Keys(OS)
+	require.True(t, ok)
+	require.Contains(t, keys, "FOO")
+}
+
+func TestSourceFunc(t *testing.T) {
+	e := New(SourceFunc(func(key string) (string, bool) {
+		return key + "!", true
+	}))
+
+	require.Equal(t, "FOO!", e.Get("FOO"))
+
+	_, ok := Keys(e.Source)
+	require.False(t, ok)
+}
@@ -0,0 +1,145 @@
+package env
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+var secretType = typeOf[Secret]()
+
+// Unmarshal populates the struct pointed to by v with the values named by its `env` field tags.
+// Fields whose value is not present are left unchanged, so v may be pre-populated with defaults.
+//
+// Nested structs are walked recursively. The tag of a struct field is prepended to the keys of its own fields,
+// so a field tagged `env:"PORT"` inside a struct field tagged `env:"DB"` is read from DB_PORT.
+// Untagged struct fields are walked without adding to the key, and fields tagged `env:"-"` are ignored.
+// The key may be followed by the secret option, e.g. `env:"PASSWORD,secret"`, which marks the field as sensitive when
+// it is declared with DeclareStruct.
+//
+// Fields may be of any type supported by Parse, including slices and maps.
+//
+// Fields may be validated with a `validate` tag listing comma-separated rules, e.g. `validate:"required,min=1"`:
+//
+//	required       the value must be present
+//	min=n, max=n   numeric and time.Duration values must be within the bound
+//	oneof=a b c    the value must be one of the space-separated options
+//	notempty       strings, slices and maps must not be empty
+//	minlen=n       strings must contain at least n characters, and slices and maps at least n elements
+//	maxlen=n       strings must contain at most n characters, and slices and maps at most n elements
+//	regexp=expr    strings must match expr; it must be the last rule, as expr may contain commas
+//
+// A *NotSetError is returned for a missing required value, and a *ValidationError for any other violated rule.
+func Unmarshal(v interface{}) error {
+	return Default.Unmarshal(v)
+}
+
+// Unmarshal populates the struct pointed to by v with the values named by its `env` field tags.
+// See Unmarshal for details on how fields are resolved.
+func (e *Env) Unmarshal(v interface{}) error {
+	return e.unmarshal(v, "")
+}
+
+func (e *Env) unmarshal(v interface{}, p Prefix) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return errors.New("env: Unmarshal requires a non-nil pointer to a struct")
+	}
+	return e.unmarshalStruct(rv.Elem(), p)
+}
+
+func (e *Env) unmarshalStruct(rv reflect.Value, p Prefix) error {
+	return walkStruct(rv, p, func(f structField) error {
+		if !f.value.CanSet() {
+			return nil
+		}
+
+		rules, required, err := e.parseValidateTag(f.value.Type(), f.field.Tag.Get("validate"))
+		if err != nil {
+			return fmt.Errorf("env: field %s (%s): invalid validate tag: %w", f.field.Name, f.key, err)
+		}
+
+		s, ok, err := e.lookup(f.key)
+		if f.secret {
+			e.secret(f.key)
+		}
+		if err != nil {
+			return err
+		}
+		if !ok || s == "" {
+			if required {
+				return &NotSetError{Key: f.key, Type: f.value.Type().String()}
+			}
+			return nil
+		}
+		v, err := e.parse(f.value.Type(), s)
+		if err == errUnsupportedType {
+			return fmt.Errorf("env: field %s (%s): unsupported type %s", f.field.Name, f.key, f.value.Type())
+		} else if err != nil {
+			return e.parseError(f.key, s, f.value.Type().String(), err)
+		}
+		for _, r := range rules {
+			if !r.valid(v) {
+				return e.failed(f.key, &ValidationError{Key: f.key, Value: s, Rule: r.name})
+			}
+		}
+		f.value.Set(v)
+		return nil
+	})
+}
+
+// structField is a tagged field of a struct walked by walkStruct.
+type structField struct {
+	field  reflect.StructField
+	value  reflect.Value
+	key    string // the name of the variable, including the tags of any parent struct fields
+	secret bool   // whether the field is tagged with the secret option or is a Secret
+}
+
+// walkStruct calls fn for each tagged field of the struct rv, recursing into nested structs as described by Unmarshal.
+func walkStruct(rv reflect.Value, p Prefix, fn func(f structField) error) error {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		fv := rv.Field(i)
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+
+		tag, tagged := field.Tag.Lookup("env")
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "-" {
+			continue
+		}
+		secret := fv.Type() == secretType
+		for _, opt := range strings.Split(opts, ",") {
+			switch strings.TrimSpace(opt) {
+			case "":
+			case "secret":
+				secret = true
+			default:
+				return fmt.Errorf("env: field %s: unknown tag option %q", field.Name, opt)
+			}
+		}
+
+		if fv.Kind() == reflect.Struct && !hasParser(fv.Type()) {
+			np := p
+			if tagged && name != "" {
+				np = Prefix(p.key(name))
+			}
+			if err := walkStruct(fv, np, fn); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if !tagged || name == "" {
+			continue
+		}
+		if err := fn(structField{field: field, value: fv, key: p.key(name), secret: secret}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package env
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type unmarshalDB struct {
+	Host string `env:"HOST"`
+	Port uint16 `env:"PORT"`
+}
+
+type unmarshalConfig struct {
+	Name     string        `env:"NAME"`
+	Int      int           `env:"INT"`
+	Int8     int8          `env:"INT8"`
+	Int16    int16         `env:"INT16"`
+	Int32    int32         `env:"INT32"`
+	Int64    int64         `env:"INT64"`
+	UInt     uint          `env:"UINT"`
+	UInt8    uint8         `env:"UINT8"`
+	UInt32   uint32        `env:"UINT32"`
+	UInt64   uint64        `env:"UINT64"`
+	Float32  float32       `env:"FLOAT32"`
+	Float64  float64       `env:"FLOAT64"`
+	Bool     bool          `env:"BOOL"`
+	Duration time.Duration `env:"DURATION"`
+	Default  string        `env:"DEFAULT"`
+	Ignored  string        `env:"-"`
+	Untagged string
+
+	DB unmarshalDB `env:"DB"`
+	unmarshalEmbedded
+}
+
+type unmarshalEmbedded struct {
+	Embedded string `env:"EMBEDDED"`
+}
+
+func TestUnmarshal(t *testing.T) {
+	values := map[string]string{
+		"NAME":     "app",
+		"INT":      "-1",
+		"INT8":     "-8",
+		"INT16":    "-16",
+		"INT32":    "-32",
+		"INT64":    "-64",
+		"UINT":     "1",
+		"UINT8":    "8",
+		"UINT32":   "32",
+		"UINT64":   "64",
+		"FLOAT32":  "1.5",
+		"FLOAT64":  "2.5",
+		"BOOL":     "yes",
+		"DURATION": "5s",
+		"DEFAULT":  "",
+		"DB_HOST":  "localhost",
+		"DB_PORT":  "5432",
+		"EMBEDDED": "embedded",
+	}
+	cfg := unmarshalConfig{Default: "default", Ignored: "ignored"}
+	require.NoError(t, New(Map(values)).Unmarshal(&cfg))
+	require.Equal(t, unmarshalConfig{
+		Name:              "app",
+		Int:               -1,
+		Int8:              -8,
+		Int16:             -16,
+		Int32:             -32,
+		Int64:             -64,
+		UInt:              1,
+		UInt8:             8,
+		UInt32:            32,
+		UInt64:            64,
+		Float32:           1.5,
+		Float64:           2.5,
+		Bool:              true,
+		Duration:          5 * time.Second,
+		Default:           "default",
+		Ignored:           "ignored",
+		DB:                unmarshalDB{Host: "localhost", Port: 5432},
+		unmarshalEmbedded: unmarshalEmbedded{Embedded: "embedded"},
+	}, cfg)
+}
+
+func TestPrefix_Unmarshal(t *testing.T) {
+	t.Setenv("APP_DB_HOST", "db.local")
+	t.Setenv("APP_DB_PORT", "3306")
+
+	var cfg struct {
+		DB unmarshalDB `env:"DB"`
+	}
+	require.NoError(t, Prefix("APP").Unmarshal(&cfg))
+	require.Equal(t, unmarshalDB{Host: "db.local", Port: 3306}, cfg.DB)
+}
+
+func TestUnmarshal_Invalid(t *testing.T) {
+	var cfg unmarshalConfig
+	require.Error(t, Unmarshal(cfg))
+	require.Error(t, Unmarshal((*unmarshalConfig)(nil)))
+
+	t.Setenv("UNSUPPORTED", "1")
+	var unsupported struct {
+		C complex64 `env:"UNSUPPORTED"`
+	}
+	require.Error(t, Unmarshal(&unsupported))
+}
+
+func TestUnmarshal_ParseError(t *testing.T) {
+	t.Setenv("MALFORMED", "80a")
+
+	var cfg struct {
+		Port int `env:"MALFORMED"`
+	}
+	err := Unmarshal(&cfg)
+
+	var parseErr *ParseError
+	require.True(t, errors.As(err, &parseErr))
+	require.Equal(t, &ParseError{Key: "MALFORMED", Value: "80a", Type: "int", Err: strconv.ErrSyntax}, parseErr)
+}
+
+func TestUnmarshal_SlicesAndMaps(t *testing.T) {
+	t.Setenv("HOSTS", "a.com, b.com")
+	t.Setenv("LABELS", "team:core")
+	t.Setenv("GENERIC_PORT", "8080")
+
+	var cfg struct {
+		Hosts  []string          `env:"HOSTS"`
+		Labels map[string]string `env:"LABELS"`
+		Port   genericPort       `env:"GENERIC_PORT"`
+	}
+	require.NoError(t, Unmarshal(&cfg))
+	require.Equal(t, []string{"a.com", "b.com"}, cfg.Hosts)
+	require.Equal(t, map[string]string{"team": "core"}, cfg.Labels)
+	require.Equal(t, genericPort(8080), cfg.Port)
+}
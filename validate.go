@@ -0,0 +1,228 @@
+package env
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ValidationError records a value that was parsed successfully but violates a validation rule.
+type ValidationError struct {
+	Key   string // the name of the variable
+	Value string // the raw value
+	Rule  string // the violated rule, e.g. "min=1"
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("env: %s: value %q violates %s", e.Key, e.Value, e.Rule)
+}
+
+// Rule validates values of type T, and is passed to the generic getters, e.g. Parse, Require and GetOr.
+type Rule[T any] struct {
+	name  string
+	valid func(v T) bool
+}
+
+// NewRule returns a Rule named name that reports whether a value is valid using valid.
+// The name is reported as the Rule of a *ValidationError.
+func NewRule[T any](name string, valid func(v T) bool) Rule[T] {
+	return Rule[T]{name: name, valid: valid}
+}
+
+// String returns the name of the Rule.
+func (r Rule[T]) String() string {
+	return r.name
+}
+
+type ordered interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64 | ~string
+}
+
+// Min requires a value to be greater than or equal to min.
+func Min[T ordered](min T) Rule[T] {
+	return NewRule(fmt.Sprintf("min=%v", min), func(v T) bool { return v >= min })
+}
+
+// Max requires a value to be less than or equal to max.
+func Max[T ordered](max T) Rule[T] {
+	return NewRule(fmt.Sprintf("max=%v", max), func(v T) bool { return v <= max })
+}
+
+// OneOf requires a value to be equal to one of values.
+func OneOf[T comparable](values ...T) Rule[T] {
+	names := make([]string, len(values))
+	for i, v := range values {
+		names[i] = fmt.Sprint(v)
+	}
+	return NewRule("oneof="+strings.Join(names, " "), func(v T) bool {
+		for _, value := range values {
+			if v == value {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// Match requires a string to match re.
+func Match(re *regexp.Regexp) Rule[string] {
+	return NewRule("regexp="+re.String(), re.MatchString)
+}
+
+// NotEmpty requires a string to not be empty or consist only of whitespace.
+func NotEmpty() Rule[string] {
+	return NewRule("notempty", func(v string) bool { return strings.TrimSpace(v) != "" })
+}
+
+// MinLen requires a string to contain at least n characters.
+func MinLen(n int) Rule[string] {
+	return NewRule(fmt.Sprintf("minlen=%d", n), func(v string) bool { return len([]rune(v)) >= n })
+}
+
+// MaxLen requires a string to contain at most n characters.
+func MaxLen(n int) Rule[string] {
+	return NewRule(fmt.Sprintf("maxlen=%d", n), func(v string) bool { return len([]rune(v)) <= n })
+}
+
+func validate[T any](key, raw string, v T, rules []Rule[T]) error {
+	for _, r := range rules {
+		if !r.valid(v) {
+			return &ValidationError{Key: key, Value: raw, Rule: r.name}
+		}
+	}
+	return nil
+}
+
+// tagRule validates a field value parsed by Unmarshal.
+type tagRule struct {
+	name  string
+	valid func(v reflect.Value) bool
+}
+
+// parseValidateTag parses the `validate` tag of a field of type t into its rules.
+// Rules are separated by commas. A regexp rule consumes the rest of the tag, so its pattern may contain commas.
+// required is reported separately, as it is checked before the value is parsed.
+func (e *Env) parseValidateTag(t reflect.Type, tag string) (rules []tagRule, required bool, err error) {
+	for tag != "" {
+		var rule string
+		if strings.HasPrefix(tag, "regexp=") {
+			rule, tag = tag, ""
+		} else if i := strings.IndexByte(tag, ','); i >= 0 {
+			rule, tag = tag[:i], tag[i+1:]
+		} else {
+			rule, tag = tag, ""
+		}
+
+		name, arg, _ := strings.Cut(strings.TrimSpace(rule), "=")
+		var valid func(v reflect.Value) bool
+		switch name {
+		case "required":
+			required = true
+			continue
+		case "min", "max":
+			valid, err = e.boundRule(t, name == "min", arg)
+		case "oneof":
+			valid, err = e.oneOfRule(t, strings.Fields(arg))
+		case "regexp":
+			valid, err = regexpRule(t, arg)
+		case "notempty":
+			if t.Kind() == reflect.String {
+				valid = func(v reflect.Value) bool { return strings.TrimSpace(v.String()) != "" }
+			} else {
+				valid, err = lenRule(t, func(n int) bool { return n > 0 })
+			}
+		case "minlen", "maxlen":
+			var n int
+			if n, err = strconv.Atoi(arg); err == nil {
+				min := name == "minlen"
+				valid, err = lenRule(t, func(l int) bool { return (min && l >= n) || (!min && l <= n) })
+			}
+		default:
+			err = fmt.Errorf("unknown rule %q", name)
+		}
+		if err != nil {
+			return nil, false, fmt.Errorf("%s: %w", rule, err)
+		}
+		rules = append(rules, tagRule{name: strings.TrimSpace(rule), valid: valid})
+	}
+	return rules, required, nil
+}
+
+func (e *Env) boundRule(t reflect.Type, min bool, arg string) (func(v reflect.Value) bool, error) {
+	bound, err := e.parse(t, arg)
+	if err != nil {
+		return nil, err
+	}
+
+	var cmp func(a, b reflect.Value) int
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		cmp = func(a, b reflect.Value) int { return compare(a.Int(), b.Int()) }
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		cmp = func(a, b reflect.Value) int { return compare(a.Uint(), b.Uint()) }
+	case reflect.Float32, reflect.Float64:
+		cmp = func(a, b reflect.Value) int { return compare(a.Float(), b.Float()) }
+	default:
+		return nil, fmt.Errorf("not supported by %s", t)
+	}
+
+	if min {
+		return func(v reflect.Value) bool { return cmp(v, bound) >= 0 }, nil
+	}
+	return func(v reflect.Value) bool { return cmp(v, bound) <= 0 }, nil
+}
+
+func (e *Env) oneOfRule(t reflect.Type, options []string) (func(v reflect.Value) bool, error) {
+	values := make([]reflect.Value, len(options))
+	for i, option := range options {
+		v, err := e.parse(t, option)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = v
+	}
+
+	return func(v reflect.Value) bool {
+		for _, value := range values {
+			if reflect.DeepEqual(v.Interface(), value.Interface()) {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
+
+func regexpRule(t reflect.Type, pattern string) (func(v reflect.Value) bool, error) {
+	if t.Kind() != reflect.String {
+		return nil, fmt.Errorf("not supported by %s", t)
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return func(v reflect.Value) bool { return re.MatchString(v.String()) }, nil
+}
+
+func lenRule(t reflect.Type, valid func(n int) bool) (func(v reflect.Value) bool, error) {
+	switch t.Kind() {
+	case reflect.String:
+		return func(v reflect.Value) bool { return valid(len([]rune(v.String()))) }, nil
+	case reflect.Slice, reflect.Map:
+		return func(v reflect.Value) bool { return valid(v.Len()) }, nil
+	}
+	return nil, fmt.Errorf("not supported by %s", t)
+}
+
+func compare[T ordered](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
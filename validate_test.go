@@ -0,0 +1,154 @@
+package env
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse_Rules(t *testing.T) {
+	e := New(Map{
+		"PORT":    "0",
+		"TIMEOUT": "90s",
+		"MODE":    "prod",
+		"NAME":    "  ",
+		"REGION":  "us-east-1",
+	})
+
+	tests := []struct {
+		name string
+		fn   func() error
+
+		rule string
+	}{
+		{
+			name: "min",
+			fn: func() error {
+				_, err := ParseIn(e, "PORT", Min[uint16](1), Max[uint16](65535))
+				return err
+			},
+			rule: "min=1",
+		},
+		{
+			name: "max duration",
+			fn: func() error {
+				_, err := ParseIn(e, "TIMEOUT", Max(time.Minute))
+				return err
+			},
+			rule: "max=1m0s",
+		},
+		{
+			name: "oneof",
+			fn: func() error {
+				_, err := ParseIn(e, "MODE", OneOf("dev", "staging"))
+				return err
+			},
+			rule: "oneof=dev staging",
+		},
+		{
+			name: "notempty",
+			fn: func() error {
+				_, err := ParseIn(e, "NAME", NotEmpty())
+				return err
+			},
+			rule: "notempty",
+		},
+		{
+			name: "regexp",
+			fn: func() error {
+				_, err := ParseIn(e, "REGION", Match(regexp.MustCompile(`^[a-z]{2}$`)))
+				return err
+			},
+			rule: "regexp=^[a-z]{2}$",
+		},
+		{
+			name: "maxlen",
+			fn: func() error {
+				_, err := ParseIn(e, "REGION", MinLen(2), MaxLen(5))
+				return err
+			},
+			rule: "maxlen=5",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var validationErr *ValidationError
+			require.True(t, errors.As(test.fn(), &validationErr))
+			require.Equal(t, test.rule, validationErr.Rule)
+		})
+	}
+
+	require.Equal(t, "prod", GetAsIn(e, "MODE", OneOf("dev", "prod")))
+	require.Equal(t, 30*time.Second, GetOrIn(e, "TIMEOUT", 30*time.Second, Max(time.Minute)))
+	require.Equal(t, "us-east-1", GetAsIn(e, "REGION", NewRule("has dash", func(v string) bool { return len(v) > 2 && v[2] == '-' })))
+
+	_, err := ParseIn(e, "PORT", Min(1))
+	require.EqualError(t, err, `env: PORT: value "0" violates min=1`)
+}
+
+func TestUnmarshal_Validate(t *testing.T) {
+	type config struct {
+		Port    uint16            `env:"PORT" validate:"required,min=1,max=65535"`
+		Timeout time.Duration     `env:"TIMEOUT" validate:"min=1s,max=1m"`
+		Mode    string            `env:"MODE" validate:"oneof=dev prod"`
+		Name    string            `env:"NAME" validate:"notempty,maxlen=8"`
+		Hosts   []string          `env:"HOSTS" validate:"minlen=1,maxlen=2"`
+		Region  string            `env:"REGION" validate:"regexp=^[a-z]{2}(,[a-z]{2})*$"`
+		Labels  map[string]string `env:"LABELS" validate:"notempty"`
+	}
+
+	valid := Map{
+		"PORT":    "8080",
+		"TIMEOUT": "5s",
+		"MODE":    "dev",
+		"NAME":    "app",
+		"HOSTS":   "a,b",
+		"REGION":  "us,eu",
+		"LABELS":  "a:b",
+	}
+
+	var cfg config
+	require.NoError(t, New(valid).Unmarshal(&cfg))
+
+	tests := []struct {
+		key   string
+		value string
+		rule  string
+	}{
+		{key: "PORT", value: "0", rule: "min=1"},
+		{key: "TIMEOUT", value: "2m", rule: "max=1m"},
+		{key: "MODE", value: "test", rule: "oneof=dev prod"},
+		{key: "NAME", value: " ", rule: "notempty"},
+		{key: "NAME", value: "application", rule: "maxlen=8"},
+		{key: "HOSTS", value: "a,b,c", rule: "maxlen=2"},
+		{key: "REGION", value: "usa", rule: "regexp=^[a-z]{2}(,[a-z]{2})*$"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.key+"="+test.value, func(t *testing.T) {
+			m := Map{}
+			for k, v := range valid {
+				m[k] = v
+			}
+			m[test.key] = test.value
+
+			var cfg config
+			err := New(m).Unmarshal(&cfg)
+			require.Equal(t, &ValidationError{Key: test.key, Value: test.value, Rule: test.rule}, err)
+		})
+	}
+
+	m := Map{"TIMEOUT": "5s"}
+	err := New(m).Unmarshal(&cfg)
+	require.True(t, errors.Is(err, ErrNotSet))
+	require.EqualError(t, err, "env: PORT: not set, expected uint16")
+
+	var invalid struct {
+		Name string `env:"NAME" validate:"min=1"`
+	}
+	require.EqualError(t, New(valid).Unmarshal(&invalid), "env: field Name (NAME): invalid validate tag: min=1: not supported by string")
+}
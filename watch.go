@@ -0,0 +1,260 @@
+package env
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Loader loads a snapshot of the values of a Watcher.
+type Loader func() (Map, error)
+
+// DotenvLoader returns a Loader reading the named .env files.
+// A variable defined in more than one file takes the value of the last, as with OverloadDotenv.
+func DotenvLoader(filenames ...string) Loader {
+	return func() (Map, error) {
+		m := Map{}
+		for _, filename := range filenames {
+			fm, err := ReadDotenv(filename)
+			if err != nil {
+				return nil, err
+			}
+			for k, v := range fm {
+				m[k] = v
+			}
+		}
+		return m, nil
+	}
+}
+
+// SourceLoader returns a Loader taking a snapshot of src, which must implement Enumerator.
+func SourceLoader(src Source) Loader {
+	return func() (Map, error) {
+		keys, ok := Keys(src)
+		if !ok {
+			return nil, errors.New("env: SourceLoader requires a Source implementing Enumerator")
+		}
+		m := make(Map, len(keys))
+		for _, k := range keys {
+			if v, ok := src.Lookup(k); ok {
+				m[k] = v
+			}
+		}
+		return m, nil
+	}
+}
+
+// Change describes a variable whose value changed when a Watcher reloaded.
+type Change struct {
+	Key    string
+	Old    string // the previous value, if OldSet
+	New    string // the current value, if NewSet
+	OldSet bool   // whether the variable was present before the reload
+	NewSet bool   // whether the variable is present after the reload
+}
+
+// Watcher is a Source holding a snapshot of the values loaded by a Loader, which is reloaded on an interval or
+// signal, notifying subscribers of the values that changed. It is safe for concurrent use, and readers always see a
+// complete snapshot.
+type Watcher struct {
+	// Interval is the polling interval of Run. If zero, values are only reloaded on Signals or by calling Reload.
+	Interval time.Duration
+
+	// Signals trigger a reload while Run is running, e.g. syscall.SIGHUP.
+	Signals []os.Signal
+
+	// OnError is called with the errors of reloads triggered by Run. The previous snapshot is kept on error.
+	OnError func(err error)
+
+	load     Loader
+	snapshot atomic.Pointer[Map]
+	reloadMu sync.Mutex // serializes reloads, so subscribers see changes in order
+
+	mu     sync.Mutex // guards subs and nextID
+	subs   map[int]subscription
+	nextID int
+}
+
+type subscription struct {
+	match func(key string) bool
+	fn    func(c Change)
+}
+
+// NewWatcher returns a Watcher over the values loaded by load, which is called once to load the initial snapshot.
+func NewWatcher(load Loader) (*Watcher, error) {
+	m, err := load()
+	if err != nil {
+		return nil, err
+	}
+
+	w := &Watcher{load: load, subs: map[int]subscription{}}
+	w.snapshot.Store(&m)
+	return w, nil
+}
+
+// Lookup retrieves the value named by key from the current snapshot and reports whether it is present.
+func (w *Watcher) Lookup(key string) (string, bool) {
+	return w.snapshot.Load().Lookup(key)
+}
+
+// Keys returns the keys present in the current snapshot in sorted order.
+func (w *Watcher) Keys() []string {
+	return w.snapshot.Load().Keys()
+}
+
+// Subscribe calls fn with the Change of the variable named by key after each reload that changes it.
+// The returned function cancels the subscription.
+func (w *Watcher) Subscribe(key string, fn func(c Change)) (cancel func()) {
+	return w.subscribe(func(k string) bool { return k == key }, fn)
+}
+
+// SubscribePrefix calls fn with the Change of each variable with the prefix p after each reload that changes it.
+// The returned function cancels the subscription.
+func (w *Watcher) SubscribePrefix(p Prefix, fn func(c Change)) (cancel func()) {
+	prefix := p.format("")
+	return w.subscribe(func(k string) bool { return strings.HasPrefix(k, prefix) }, fn)
+}
+
+func (w *Watcher) subscribe(match func(key string) bool, fn func(c Change)) func() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	id := w.nextID
+	w.nextID++
+	w.subs[id] = subscription{match: match, fn: fn}
+	return func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+
+		delete(w.subs, id)
+	}
+}
+
+// OnChange calls fn with the previous and current values of the variable named by key in s, parsed as a T with the
+// separators of its Env, after each reload that changes it. A value that is not present or is not a valid T is passed
+// as the zero value of T, and changes that parse to equal values are not reported.
+// The returned function cancels the subscription.
+//
+//	env.OnChange(w, env.Default, "LOG_LEVEL", func(old, new string) { ... })
+func OnChange[T any](w *Watcher, s Scope, key string, fn func(old, new T)) (cancel func()) {
+	_, name := s.scope(key)
+	t := typeOf[T]()
+	parse := func(v string, ok bool) T {
+		var zero T
+		if !ok || v == "" {
+			return zero
+		}
+		e, _ := s.scope(key)
+		rv, err := e.parse(t, v)
+		if err != nil {
+			return zero
+		}
+		return rv.Interface().(T)
+	}
+
+	return w.Subscribe(name, func(c Change) {
+		old, new := parse(c.Old, c.OldSet), parse(c.New, c.NewSet)
+		if !reflect.DeepEqual(old, new) {
+			fn(old, new)
+		}
+	})
+}
+
+// Reload loads a new snapshot and notifies the subscribers of each changed variable, in key order.
+// Subscribers are called after the new snapshot is in place, and may cancel subscriptions.
+// The previous snapshot is kept if the Loader returns an error.
+func (w *Watcher) Reload() error {
+	w.reloadMu.Lock()
+	defer w.reloadMu.Unlock()
+
+	m, err := w.load()
+	if err != nil {
+		return err
+	}
+
+	old := *w.snapshot.Swap(&m)
+	changes := diff(old, m)
+	if len(changes) == 0 {
+		return nil
+	}
+	subs := w.subscriptions()
+	for _, c := range changes {
+		for _, sub := range subs {
+			if sub.match(c.Key) {
+				sub.fn(c)
+			}
+		}
+	}
+	return nil
+}
+
+// subscriptions returns the subscriptions in the order they were made.
+func (w *Watcher) subscriptions() []subscription {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	ids := make([]int, 0, len(w.subs))
+	for id := range w.subs {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	subs := make([]subscription, len(ids))
+	for i, id := range ids {
+		subs[i] = w.subs[id]
+	}
+	return subs
+}
+
+// Run reloads on each Interval and on each of Signals until ctx is done, reporting errors to OnError.
+// It returns the error of ctx.
+func (w *Watcher) Run(ctx context.Context) error {
+	var tick <-chan time.Time
+	if w.Interval > 0 {
+		ticker := time.NewTicker(w.Interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	var sig chan os.Signal
+	if len(w.Signals) > 0 {
+		sig = make(chan os.Signal, 1)
+		signal.Notify(sig, w.Signals...)
+		defer signal.Stop(sig)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-tick:
+		case <-sig:
+		}
+		if err := w.Reload(); err != nil && w.OnError != nil {
+			w.OnError(err)
+		}
+	}
+}
+
+func diff(old, new Map) []Change {
+	var changes []Change
+	for k, ov := range old {
+		nv, ok := new[k]
+		if !ok || nv != ov {
+			changes = append(changes, Change{Key: k, Old: ov, New: nv, OldSet: true, NewSet: ok})
+		}
+	}
+	for k, nv := range new {
+		if _, ok := old[k]; !ok {
+			changes = append(changes, Change{Key: k, New: nv, NewSet: true})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Key < changes[j].Key })
+	return changes
+}
@@ -0,0 +1,129 @@
+package env
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatcher(t *testing.T) {
+	m := Map{"APP_PORT": "8080", "APP_HOST": "localhost", "OTHER": "x"}
+	w, err := NewWatcher(func() (Map, error) { return m, nil })
+	require.NoError(t, err)
+
+	e := New(w)
+	require.Equal(t, 8080, e.GetInt("APP_PORT"))
+
+	var key, prefix []Change
+	w.Subscribe("APP_PORT", func(c Change) { key = append(key, c) })
+	cancel := w.SubscribePrefix("APP", func(c Change) { prefix = append(prefix, c) })
+
+	m = Map{"APP_PORT": "9090", "APP_DEBUG": "true", "OTHER": "y"}
+	require.NoError(t, w.Reload())
+	require.Equal(t, 9090, e.GetInt("APP_PORT"))
+
+	require.Equal(t, []Change{{Key: "APP_PORT", Old: "8080", New: "9090", OldSet: true, NewSet: true}}, key)
+	require.Equal(t, []Change{
+		{Key: "APP_DEBUG", New: "true", NewSet: true},
+		{Key: "APP_HOST", Old: "localhost", OldSet: true},
+		{Key: "APP_PORT", Old: "8080", New: "9090", OldSet: true, NewSet: true},
+	}, prefix)
+
+	cancel()
+	m = Map{"APP_PORT": "80"}
+	require.NoError(t, w.Reload())
+	require.Len(t, key, 2)
+	require.Len(t, prefix, 3)
+}
+
+func TestWatcher_ReloadError(t *testing.T) {
+	var fail error
+	w, err := NewWatcher(func() (Map, error) { return Map{"FOO": "BAR"}, fail })
+	require.NoError(t, err)
+
+	fail = errors.New("boom")
+	require.Equal(t, fail, w.Reload())
+	require.Equal(t, "BAR", New(w).Get("FOO"))
+
+	_, err = NewWatcher(func() (Map, error) { return nil, fail })
+	require.Equal(t, fail, err)
+}
+
+func TestOnChange(t *testing.T) {
+	m := Map{"TIMEOUT": "5s"}
+	w, err := NewWatcher(func() (Map, error) { return m, nil })
+	require.NoError(t, err)
+
+	var got [][2]time.Duration
+	OnChange(w, New(w), "TIMEOUT", func(old, new time.Duration) { got = append(got, [2]time.Duration{old, new}) })
+
+	for _, v := range []string{"5000ms", "10s", "invalid"} {
+		m = Map{"TIMEOUT": v}
+		require.NoError(t, w.Reload())
+	}
+	m = Map{}
+	require.NoError(t, w.Reload())
+
+	require.Equal(t, [][2]time.Duration{{5 * time.Second, 10 * time.Second}, {10 * time.Second, 0}}, got)
+}
+
+func TestOnChange_Scope(t *testing.T) {
+	m := Map{"APP_HOSTS": "a;b"}
+	w, err := NewWatcher(func() (Map, error) { return m, nil })
+	require.NoError(t, err)
+	ns := Namespace{Env: &Env{Source: w, ListSeparator: ";"}, Path: []string{"APP"}}
+
+	var got []string
+	OnChange(w, ns, "HOSTS", func(old, new []string) { got = new })
+
+	m = Map{"APP_HOSTS": "a,b;c"}
+	require.NoError(t, w.Reload())
+	require.Equal(t, []string{"a,b", "c"}, got)
+}
+
+func TestWatcher_Run(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), ".env")
+	require.NoError(t, os.WriteFile(filename, []byte("FOO=1\n"), 0o600))
+
+	w, err := NewWatcher(DotenvLoader(filename))
+	require.NoError(t, err)
+	w.Interval = time.Millisecond
+
+	changed := make(chan Change, 1)
+	w.Subscribe("FOO", func(c Change) {
+		select {
+		case changed <- c:
+		default:
+		}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errc := make(chan error, 1)
+	go func() { errc <- w.Run(ctx) }()
+
+	require.NoError(t, os.WriteFile(filename, []byte("FOO=2\n"), 0o600))
+	select {
+	case c := <-changed:
+		require.Equal(t, "2", c.New)
+	case <-time.After(5 * time.Second):
+		t.Fatal("change not reported")
+	}
+	require.Equal(t, 2, New(w).GetInt("FOO"))
+
+	cancel()
+	require.Equal(t, context.Canceled, <-errc)
+}
+
+func TestSourceLoader(t *testing.T) {
+	m, err := SourceLoader(Map{"FOO": "BAR"})()
+	require.NoError(t, err)
+	require.Equal(t, Map{"FOO": "BAR"}, m)
+
+	_, err = SourceLoader(SourceFunc(func(string) (string, bool) { return "", false }))()
+	require.Error(t, err)
+}